@@ -2,6 +2,8 @@ package ast
 
 import (
 	"bytes"
+	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
@@ -46,20 +48,25 @@ func AppendExpression(exprList ExpressionList, expr Expression) (ExpressionList,
 	return append(exprList, expr), nil
 }
 
-type ExpressionMap map[Expression]Expression
+type ExpressionPair struct {
+	Key   Expression
+	Value Expression
+}
+
+// ExpressionMap holds the pairs of a hash literal in source order.
+type ExpressionMap []ExpressionPair
 
 func NewExpressionMap(key, value Expression) (ExpressionMap, error) {
-	pairs := make(ExpressionMap)
+	pairs := ExpressionMap{}
 	if key != nil {
-		pairs[key] = value
+		pairs = append(pairs, ExpressionPair{Key: key, Value: value})
 	}
 
 	return pairs, nil
 }
 
 func AppendExpressionPair(pairs ExpressionMap, key, value Expression) (ExpressionMap, error) {
-	pairs[key] = value
-	return pairs, nil
+	return append(pairs, ExpressionPair{Key: key, Value: value}), nil
 }
 
 type Program struct {
@@ -100,6 +107,7 @@ func NewBlockStatement(t *token.Token, stmtList StatementList) (*BlockStatement,
 
 func (bs *BlockStatement) statementNode()       {}
 func (bs *BlockStatement) TokenLiteral() string { return string(bs.Token.Lit) }
+func (bs *BlockStatement) Pos() token.Pos       { return bs.Token.Pos }
 func (bs *BlockStatement) String() string {
 	var out strings.Builder
 
@@ -130,6 +138,7 @@ func NewReturnStatement(t *token.Token, rv Expression) (*ReturnStatement, error)
 
 func (rs *ReturnStatement) statementNode()       {}
 func (rs *ReturnStatement) TokenLiteral() string { return string(rs.Token.Lit) }
+func (rs *ReturnStatement) Pos() token.Pos       { return rs.Token.Pos }
 func (rs *ReturnStatement) String() string {
 	var out strings.Builder
 
@@ -142,6 +151,27 @@ func (rs *ReturnStatement) String() string {
 	return out.String()
 }
 
+// YieldStatement produces the next value of a generator. A function whose
+// body contains one is a generator function.
+type YieldStatement struct {
+	Token token.Token
+	Value Expression
+}
+
+func NewYieldStatement(t *token.Token, value Expression) (*YieldStatement, error) {
+	return &YieldStatement{Token: *t, Value: value}, nil
+}
+
+func (ys *YieldStatement) statementNode()       {}
+func (ys *YieldStatement) TokenLiteral() string { return string(ys.Token.Lit) }
+func (ys *YieldStatement) Pos() token.Pos       { return ys.Token.Pos }
+func (ys *YieldStatement) String() string {
+	if ys.Value == nil {
+		return ys.TokenLiteral()
+	}
+	return ys.TokenLiteral() + " " + ys.Value.String()
+}
+
 type ExpressionStatement struct {
 	Expression Expression
 }
@@ -178,6 +208,7 @@ func NewPrefixExpression(t *token.Token, right Expression) (*PrefixExpression, e
 
 func (pe *PrefixExpression) expressionNode()      {}
 func (pe *PrefixExpression) TokenLiteral() string { return string(pe.Token.Lit) }
+func (pe *PrefixExpression) Pos() token.Pos       { return pe.Token.Pos }
 func (pe *PrefixExpression) String() string {
 	var out strings.Builder
 
@@ -202,6 +233,7 @@ func NewInfixExpression(left Expression, t *token.Token, right Expression) (*Inf
 
 func (ie *InfixExpression) expressionNode()      {}
 func (ie *InfixExpression) TokenLiteral() string { return string(ie.Token.Lit) }
+func (ie *InfixExpression) Pos() token.Pos       { return ie.Token.Pos }
 func (ie *InfixExpression) String() string {
 	var out strings.Builder
 
@@ -216,16 +248,20 @@ func (ie *InfixExpression) String() string {
 
 type AssignExpression struct {
 	Token token.Token
-	Left  Expression // Identifier, IndexExpression or SelectorExpression
+	Left  Expression // a name, an index, slice or selector expression or a pattern
 	Right Expression
 }
 
 func NewAssignExpression(left Expression, t *token.Token, right Expression) (*AssignExpression, error) {
+	if err := checkTarget(left, false); err != nil {
+		return nil, err
+	}
 	return &AssignExpression{Token: *t, Left: left, Right: right}, nil
 }
 
 func (ae *AssignExpression) expressionNode()      {}
 func (ae *AssignExpression) TokenLiteral() string { return string(ae.Token.Lit) }
+func (ae *AssignExpression) Pos() token.Pos       { return ae.Token.Pos }
 func (ae *AssignExpression) String() string {
 	var out strings.Builder
 
@@ -238,6 +274,46 @@ func (ae *AssignExpression) String() string {
 	return out.String()
 }
 
+// CompoundAssignExpression applies an infix operator to a target and a value
+// and assigns the result to the target, as in a += 1. Right is nil for i++
+// and i--, which add and subtract one.
+type CompoundAssignExpression struct {
+	Token    token.Token
+	Left     Expression // Identifier, IndexExpression or SelectorExpression
+	Operator string
+	Right    Expression
+}
+
+func NewCompoundAssignExpression(left Expression, t *token.Token, right Expression) (*CompoundAssignExpression, error) {
+	op := strings.TrimSuffix(string(t.Lit), "=")
+	if right == nil {
+		op = op[:1]
+	}
+	if err := checkTarget(left, false); err != nil {
+		return nil, err
+	}
+	return &CompoundAssignExpression{Token: *t, Left: left, Operator: op, Right: right}, nil
+}
+
+func (ce *CompoundAssignExpression) expressionNode()      {}
+func (ce *CompoundAssignExpression) TokenLiteral() string { return string(ce.Token.Lit) }
+func (ce *CompoundAssignExpression) Pos() token.Pos       { return ce.Token.Pos }
+func (ce *CompoundAssignExpression) String() string {
+	var out strings.Builder
+
+	out.WriteRune('(')
+	out.WriteString(ce.Left.String())
+	if ce.Right == nil {
+		out.WriteString(ce.TokenLiteral())
+	} else {
+		out.WriteString(" " + ce.TokenLiteral() + " ")
+		out.WriteString(ce.Right.String())
+	}
+	out.WriteRune(')')
+
+	return out.String()
+}
+
 type IfExpression struct {
 	Token       token.Token
 	Condition   Expression
@@ -251,6 +327,7 @@ func NewIfExpression(t *token.Token, cond Expression, conseq *BlockStatement, al
 
 func (ie *IfExpression) expressionNode()      {}
 func (ie *IfExpression) TokenLiteral() string { return string(ie.Token.Lit) }
+func (ie *IfExpression) Pos() token.Pos       { return ie.Token.Pos }
 func (ie *IfExpression) String() string {
 	var out strings.Builder
 
@@ -290,6 +367,7 @@ func NewForExpression(t *token.Token, init Expression, cond Expression, count Ex
 
 func (fe *ForExpression) expressionNode()      {}
 func (fe *ForExpression) TokenLiteral() string { return string(fe.Token.Lit) }
+func (fe *ForExpression) Pos() token.Pos       { return fe.Token.Pos }
 func (fe *ForExpression) String() string {
 	var out strings.Builder
 
@@ -321,6 +399,162 @@ func (fe *ForExpression) String() string {
 	return out.String()
 }
 
+// ForInExpression loops over the elements of an iterable object, binding
+// each to a name or a pattern.
+type ForInExpression struct {
+	Token       token.Token
+	Variable    Expression
+	Iterable    Expression
+	Consequence *BlockStatement
+}
+
+func NewForInExpression(t *token.Token, variable Expression, iterable Expression, conseq *BlockStatement) (*ForInExpression, error) {
+	return &ForInExpression{Token: *t, Variable: variable, Iterable: iterable, Consequence: conseq}, nil
+}
+
+func (fe *ForInExpression) expressionNode()      {}
+func (fe *ForInExpression) TokenLiteral() string { return string(fe.Token.Lit) }
+func (fe *ForInExpression) Pos() token.Pos       { return fe.Token.Pos }
+func (fe *ForInExpression) String() string {
+	var out strings.Builder
+
+	out.WriteString("for ")
+	out.WriteString(fe.Variable.String())
+	out.WriteString(" in ")
+	out.WriteString(fe.Iterable.String())
+	out.WriteRune(' ')
+	out.WriteString(fe.Consequence.String())
+
+	return out.String()
+}
+
+// StructMember is a field or a method in a struct declaration.
+type StructMember interface {
+	Node
+	structMember()
+}
+
+type StructMembers []StructMember
+
+func NewStructMembers(m StructMember) (StructMembers, error) {
+	return StructMembers{m}, nil
+}
+
+func AppendStructMember(ml StructMembers, m StructMember) (StructMembers, error) {
+	return append(ml, m), nil
+}
+
+// StructField declares a field and the value it has when the constructor
+// is not given one. Fields without a default are required.
+type StructField struct {
+	Name    *Identifier
+	Default Expression
+}
+
+func NewStructField(name *Identifier, def Expression) (*StructField, error) {
+	return &StructField{Name: name, Default: def}, nil
+}
+
+func (sf *StructField) structMember()        {}
+func (sf *StructField) TokenLiteral() string { return sf.Name.TokenLiteral() }
+func (sf *StructField) Pos() token.Pos       { return sf.Name.Pos() }
+func (sf *StructField) String() string {
+	if sf.Default == nil {
+		return sf.Name.String()
+	}
+	return sf.Name.String() + " = " + sf.Default.String()
+}
+
+// StructMethod declares a method, in which self refers to the instance.
+type StructMethod struct {
+	Name     *Identifier
+	Function *FunctionLiteral
+}
+
+func NewStructMethod(t *token.Token, name *Identifier, params ExpressionList, body *BlockStatement) (*StructMethod, error) {
+	fn, err := NewFunctionLiteral(t, params, body)
+	if err != nil {
+		return nil, err
+	}
+	return &StructMethod{Name: name, Function: fn}, nil
+}
+
+func (sm *StructMethod) structMember()        {}
+func (sm *StructMethod) TokenLiteral() string { return sm.Function.TokenLiteral() }
+func (sm *StructMethod) Pos() token.Pos       { return sm.Function.Pos() }
+func (sm *StructMethod) String() string {
+	var out strings.Builder
+
+	var params []string
+	for _, p := range sm.Function.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString("fn ")
+	out.WriteString(sm.Name.String())
+	out.WriteString("(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") ")
+	out.WriteString(sm.Function.Body.String())
+
+	return out.String()
+}
+
+// StructDeclaration declares a struct type and binds it to its name.
+type StructDeclaration struct {
+	Token   token.Token
+	Name    *Identifier
+	Fields  []*StructField
+	Methods []*StructMethod
+}
+
+func NewStructDeclaration(t *token.Token, name *Identifier, members StructMembers) (*StructDeclaration, error) {
+	decl := &StructDeclaration{Token: *t, Name: name}
+
+	seen := make(map[string]bool)
+	for _, m := range members {
+		switch m := m.(type) {
+		case *StructField:
+			if seen[m.Name.Value] {
+				return nil, fmt.Errorf("duplicate member %s in struct %s", m.Name.Value, name.Value)
+			}
+			seen[m.Name.Value] = true
+			decl.Fields = append(decl.Fields, m)
+		case *StructMethod:
+			if seen[m.Name.Value] {
+				return nil, fmt.Errorf("duplicate member %s in struct %s", m.Name.Value, name.Value)
+			}
+			seen[m.Name.Value] = true
+			decl.Methods = append(decl.Methods, m)
+		}
+	}
+
+	return decl, nil
+}
+
+func (sd *StructDeclaration) expressionNode()      {}
+func (sd *StructDeclaration) TokenLiteral() string { return string(sd.Token.Lit) }
+func (sd *StructDeclaration) Pos() token.Pos       { return sd.Token.Pos }
+func (sd *StructDeclaration) String() string {
+	var out strings.Builder
+
+	var members []string
+	for _, f := range sd.Fields {
+		members = append(members, f.String())
+	}
+	for _, m := range sd.Methods {
+		members = append(members, m.String())
+	}
+
+	out.WriteString("struct ")
+	out.WriteString(sd.Name.String())
+	out.WriteString(" {")
+	out.WriteString(strings.Join(members, "; "))
+	out.WriteRune('}')
+
+	return out.String()
+}
+
 type Identifier struct {
 	Token token.Token
 	Value string
@@ -332,22 +566,9 @@ func NewIdentifier(t *token.Token) (*Identifier, error) {
 
 func (i *Identifier) expressionNode()      {}
 func (i *Identifier) TokenLiteral() string { return string(i.Token.Lit) }
+func (i *Identifier) Pos() token.Pos       { return i.Token.Pos }
 func (i *Identifier) String() string       { return i.Value }
 
-type IdentifierList []*Identifier
-
-func NewIdentifierList(i *Identifier) (IdentifierList, error) {
-	if i == nil {
-		return IdentifierList{}, nil
-	}
-
-	return IdentifierList{i}, nil
-}
-
-func AppendIdentifier(il IdentifierList, i *Identifier) (IdentifierList, error) {
-	return append(il, i), nil
-}
-
 type Null struct {
 	Token token.Token
 }
@@ -358,6 +579,7 @@ func NewNull(t *token.Token) (*Null, error) {
 
 func (n *Null) expressionNode()      {}
 func (n *Null) TokenLiteral() string { return string(n.Token.Lit) }
+func (n *Null) Pos() token.Pos       { return n.Token.Pos }
 func (n *Null) String() string       { return n.TokenLiteral() }
 
 type BooleanLiteral struct {
@@ -371,24 +593,32 @@ func NewBooleanLiteral(t *token.Token) (*BooleanLiteral, error) {
 
 func (bl *BooleanLiteral) expressionNode()      {}
 func (bl *BooleanLiteral) TokenLiteral() string { return string(bl.Token.Lit) }
+func (bl *BooleanLiteral) Pos() token.Pos       { return bl.Token.Pos }
 func (bl *BooleanLiteral) String() string       { return bl.TokenLiteral() }
 
 type IntegerLiteral struct {
 	Token token.Token
 	Value int64
+	Big   *big.Int // set when the literal does not fit into int64
 }
 
 func NewIntegerLiteral(t *token.Token) (*IntegerLiteral, error) {
 	v, err := strconv.ParseInt(string(t.Lit), 0, 64)
-	if err != nil {
+	if err == nil {
+		return &IntegerLiteral{Token: *t, Value: v}, nil
+	}
+
+	b, ok := new(big.Int).SetString(string(t.Lit), 0)
+	if !ok {
 		return nil, err
 	}
 
-	return &IntegerLiteral{Token: *t, Value: v}, nil
+	return &IntegerLiteral{Token: *t, Big: b}, nil
 }
 
 func (il *IntegerLiteral) expressionNode()      {}
 func (il *IntegerLiteral) TokenLiteral() string { return string(il.Token.Lit) }
+func (il *IntegerLiteral) Pos() token.Pos       { return il.Token.Pos }
 func (il *IntegerLiteral) String() string       { return il.TokenLiteral() }
 
 type FloatLiteral struct {
@@ -407,6 +637,7 @@ func NewFloatLiteral(t *token.Token) (*FloatLiteral, error) {
 
 func (fl *FloatLiteral) expressionNode()      {}
 func (fl *FloatLiteral) TokenLiteral() string { return string(fl.Token.Lit) }
+func (fl *FloatLiteral) Pos() token.Pos       { return fl.Token.Pos }
 func (fl *FloatLiteral) String() string       { return fl.TokenLiteral() }
 
 type StringLiteral struct {
@@ -424,23 +655,36 @@ func NewStringLiteral(t *token.Token) (*StringLiteral, error) {
 
 func (sl *StringLiteral) expressionNode()      {}
 func (sl *StringLiteral) TokenLiteral() string { return string(sl.Token.Lit) }
+func (sl *StringLiteral) Pos() token.Pos       { return sl.Token.Pos }
 func (sl *StringLiteral) String() string       { return sl.TokenLiteral() }
 
 type FunctionLiteral struct {
-	Token      token.Token
-	Parameters IdentifierList
-	Body       *BlockStatement
+	Token       token.Token
+	Parameters  ExpressionList // names or patterns
+	Body        *BlockStatement
+	IsGenerator bool // the body yields outside of nested functions
 }
 
-func NewFunctionLiteral(t *token.Token, params IdentifierList, body *BlockStatement) (*FunctionLiteral, error) {
+func NewFunctionLiteral(t *token.Token, params ExpressionList, body *BlockStatement) (*FunctionLiteral, error) {
 	if params == nil {
-		params = IdentifierList{}
+		params = ExpressionList{}
 	}
-	return &FunctionLiteral{Token: *t, Parameters: params, Body: body}, nil
+	fl := &FunctionLiteral{Token: *t, Parameters: params, Body: body}
+	Inspect(body, func(node Node) bool {
+		switch node.(type) {
+		case *YieldStatement:
+			fl.IsGenerator = true
+		case *FunctionLiteral:
+			return false
+		}
+		return !fl.IsGenerator
+	})
+	return fl, nil
 }
 
 func (fl *FunctionLiteral) expressionNode()      {}
 func (fl *FunctionLiteral) TokenLiteral() string { return string(fl.Token.Lit) }
+func (fl *FunctionLiteral) Pos() token.Pos       { return fl.Token.Pos }
 func (fl *FunctionLiteral) String() string {
 	var out strings.Builder
 
@@ -470,6 +714,7 @@ func NewCallExpression(function Expression, t *token.Token, args ExpressionList)
 
 func (ce *CallExpression) expressionNode()      {}
 func (ce *CallExpression) TokenLiteral() string { return string(ce.Token.Lit) }
+func (ce *CallExpression) Pos() token.Pos       { return ce.Token.Pos }
 func (ce *CallExpression) String() string {
 	var out strings.Builder
 
@@ -500,6 +745,7 @@ func NewArrayLiteral(t *token.Token, elems ExpressionList) (*ArrayLiteral, error
 
 func (al *ArrayLiteral) expressionNode()      {}
 func (al *ArrayLiteral) TokenLiteral() string { return string(al.Token.Lit) }
+func (al *ArrayLiteral) Pos() token.Pos       { return al.Token.Pos }
 func (al *ArrayLiteral) String() string {
 	var out strings.Builder
 
@@ -515,6 +761,39 @@ func (al *ArrayLiteral) String() string {
 	return out.String()
 }
 
+type TupleLiteral struct {
+	Token    token.Token
+	Elements ExpressionList
+}
+
+func NewTupleLiteral(t *token.Token, elems ExpressionList) (*TupleLiteral, error) {
+	if elems == nil {
+		elems = ExpressionList{}
+	}
+	return &TupleLiteral{Token: *t, Elements: elems}, nil
+}
+
+func (tl *TupleLiteral) expressionNode()      {}
+func (tl *TupleLiteral) TokenLiteral() string { return string(tl.Token.Lit) }
+func (tl *TupleLiteral) Pos() token.Pos       { return tl.Token.Pos }
+func (tl *TupleLiteral) String() string {
+	var out strings.Builder
+
+	var elems []string
+	for _, el := range tl.Elements {
+		elems = append(elems, el.String())
+	}
+
+	out.WriteRune('(')
+	out.WriteString(strings.Join(elems, ", "))
+	if len(elems) == 1 {
+		out.WriteRune(',')
+	}
+	out.WriteRune(')')
+
+	return out.String()
+}
+
 type HashLiteral struct {
 	Token token.Token
 	Pairs ExpressionMap
@@ -529,12 +808,13 @@ func NewHashLiteral(t *token.Token, pairs ExpressionMap) (*HashLiteral, error) {
 
 func (hl *HashLiteral) expressionNode()      {}
 func (hl *HashLiteral) TokenLiteral() string { return string(hl.Token.Lit) }
+func (hl *HashLiteral) Pos() token.Pos       { return hl.Token.Pos }
 func (hl *HashLiteral) String() string {
 	var out strings.Builder
 
 	var pairs []string
-	for k, v := range hl.Pairs {
-		pairs = append(pairs, k.String()+":"+v.String())
+	for _, pair := range hl.Pairs {
+		pairs = append(pairs, pair.Key.String()+":"+pair.Value.String())
 	}
 
 	out.WriteRune('{')
@@ -544,6 +824,36 @@ func (hl *HashLiteral) String() string {
 	return out.String()
 }
 
+type SetLiteral struct {
+	Token    token.Token
+	Elements ExpressionList
+}
+
+func NewSetLiteral(t *token.Token, elems ExpressionList) (*SetLiteral, error) {
+	return &SetLiteral{Token: *t, Elements: elems}, nil
+}
+
+func (sl *SetLiteral) expressionNode()      {}
+func (sl *SetLiteral) TokenLiteral() string { return string(sl.Token.Lit) }
+func (sl *SetLiteral) Pos() token.Pos       { return sl.Token.Pos }
+func (sl *SetLiteral) String() string {
+	var out strings.Builder
+
+	var elems []string
+	for _, el := range sl.Elements {
+		elems = append(elems, el.String())
+	}
+
+	out.WriteRune('{')
+	out.WriteString(strings.Join(elems, ", "))
+	if len(elems) == 1 {
+		out.WriteRune(',')
+	}
+	out.WriteRune('}')
+
+	return out.String()
+}
+
 type IndexExpression struct {
 	Token token.Token
 	Left  Expression
@@ -556,6 +866,7 @@ func NewIndexExpression(left Expression, t *token.Token, index Expression) (*Ind
 
 func (ie *IndexExpression) expressionNode()      {}
 func (ie *IndexExpression) TokenLiteral() string { return string(ie.Token.Lit) }
+func (ie *IndexExpression) Pos() token.Pos       { return ie.Token.Pos }
 func (ie *IndexExpression) String() string {
 	var out strings.Builder
 
@@ -569,23 +880,67 @@ func (ie *IndexExpression) String() string {
 	return out.String()
 }
 
-type SelectorExpression struct {
+// SliceExpression is left[start:end] or left[start:end:step]. The bounds
+// which are left out are nil.
+type SliceExpression struct {
 	Token token.Token
 	Left  Expression
-	Right *Identifier
+	Start Expression
+	End   Expression
+	Step  Expression
+}
+
+func NewSliceExpression(left Expression, t *token.Token, start, end, step Expression) (*SliceExpression, error) {
+	return &SliceExpression{Left: left, Token: *t, Start: start, End: end, Step: step}, nil
+}
+
+func (se *SliceExpression) expressionNode()      {}
+func (se *SliceExpression) TokenLiteral() string { return string(se.Token.Lit) }
+func (se *SliceExpression) Pos() token.Pos       { return se.Token.Pos }
+func (se *SliceExpression) String() string {
+	var out strings.Builder
+
+	out.WriteRune('(')
+	out.WriteString(se.Left.String())
+	out.WriteRune('[')
+	if se.Start != nil {
+		out.WriteString(se.Start.String())
+	}
+	out.WriteRune(':')
+	if se.End != nil {
+		out.WriteString(se.End.String())
+	}
+	if se.Step != nil {
+		out.WriteRune(':')
+		out.WriteString(se.Step.String())
+	}
+	out.WriteRune(']')
+	out.WriteRune(')')
+
+	return out.String()
+}
+
+// SelectorExpression is left.right, or left?.right when Optional, which
+// skips the rest of the selector, index and call chain if left is null.
+type SelectorExpression struct {
+	Token    token.Token
+	Left     Expression
+	Right    *Identifier
+	Optional bool
 }
 
 func NewSelectorExpression(left Expression, t *token.Token, right *Identifier) (*SelectorExpression, error) {
-	return &SelectorExpression{Left: left, Token: *t, Right: right}, nil
+	return &SelectorExpression{Left: left, Token: *t, Right: right, Optional: string(t.Lit) == "?."}, nil
 }
 
 func (se *SelectorExpression) expressionNode()      {}
 func (se *SelectorExpression) TokenLiteral() string { return string(se.Token.Lit) }
+func (se *SelectorExpression) Pos() token.Pos       { return se.Token.Pos }
 func (se *SelectorExpression) String() string {
 	var out strings.Builder
 
 	out.WriteString(se.Left.String())
-	out.WriteRune('.')
+	out.WriteString(se.TokenLiteral())
 	out.WriteString(se.Right.String())
 
 	return out.String()
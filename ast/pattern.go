@@ -0,0 +1,152 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ars2014/ulang/token"
+)
+
+// SpreadElement is ...value in an array literal. It inserts the elements of
+// an iterable, or collects the remaining elements in an array pattern.
+type SpreadElement struct {
+	Token token.Token
+	Value Expression
+}
+
+func NewSpreadElement(t *token.Token, value Expression) (*SpreadElement, error) {
+	return &SpreadElement{Token: *t, Value: value}, nil
+}
+
+func (se *SpreadElement) expressionNode()      {}
+func (se *SpreadElement) TokenLiteral() string { return string(se.Token.Lit) }
+func (se *SpreadElement) Pos() token.Pos       { return se.Token.Pos }
+func (se *SpreadElement) String() string       { return se.TokenLiteral() + se.Value.String() }
+
+// NewPattern checks that a parameter or the variable of a for-in loop only
+// binds names.
+func NewPattern(pattern Expression) (Expression, error) {
+	if err := checkTarget(pattern, true); err != nil {
+		return nil, err
+	}
+	return pattern, nil
+}
+
+// NewTuplePattern returns the tuple pattern of the comma separated patterns
+// in for a, b in pairs.
+func NewTuplePattern(t *token.Token, first Expression, rest ExpressionList) *TupleLiteral {
+	return &TupleLiteral{Token: *t, Elements: append(ExpressionList{first}, rest...)}
+}
+
+// NewBlockPattern returns the pattern {name} of a block which only holds
+// the name.
+func NewBlockPattern(block *BlockStatement) (*SetLiteral, error) {
+	if len(block.Statements) == 1 {
+		if stmt, ok := block.Statements[0].(*ExpressionStatement); ok {
+			if ident, ok := stmt.Expression.(*Identifier); ok {
+				return &SetLiteral{Token: block.Token, Elements: ExpressionList{ident}}, nil
+			}
+		}
+	}
+	var statements []string
+	for _, s := range block.Statements {
+		statements = append(statements, s.String())
+	}
+	return nil, fmt.Errorf("expected a name in pattern {%s}", strings.Join(statements, "; "))
+}
+
+// NewMultipleAssignment returns a, b = x, y as the assignment of a tuple to
+// a tuple pattern. The last target and the first value are parsed as assign.
+// With a single value, a, b = pair, the value itself is destructured.
+func NewMultipleAssignment(first Expression, t *token.Token, targets ExpressionList, assign *AssignExpression, values ExpressionList) (*AssignExpression, error) {
+	left := NewTuplePattern(t, first, append(targets, assign.Left))
+	right := assign.Right
+	if values != nil {
+		right = &TupleLiteral{Token: *t, Elements: append(ExpressionList{right}, values...)}
+	}
+	return NewAssignExpression(left, &assign.Token, right)
+}
+
+// checkTarget reports an error unless a value can be assigned to target. A
+// target is a name, an index, slice or selector expression, or a pattern
+// of them. With binding only names are allowed.
+func checkTarget(target Expression, binding bool) error {
+	switch t := target.(type) {
+	case *Identifier:
+		return nil
+
+	case *IndexExpression, *SliceExpression, *SelectorExpression:
+		if binding {
+			return fmt.Errorf("cannot bind to %s", target)
+		}
+		if isOptionalChain(target) {
+			return fmt.Errorf("cannot assign to %s", target)
+		}
+		return nil
+
+	case *ArrayLiteral:
+		spread := false
+		for _, el := range t.Elements {
+			if s, ok := el.(*SpreadElement); ok {
+				if spread {
+					return fmt.Errorf("multiple spread elements in pattern %s", target)
+				}
+				spread = true
+				el = s.Value
+			}
+			if err := checkTarget(el, binding); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *TupleLiteral:
+		for _, el := range t.Elements {
+			if err := checkTarget(el, binding); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *SetLiteral:
+		for _, el := range t.Elements {
+			if _, ok := el.(*Identifier); !ok {
+				return fmt.Errorf("expected a name in pattern %s got %s", target, el)
+			}
+		}
+		return nil
+
+	case *HashLiteral:
+		for _, pair := range t.Pairs {
+			if err := checkTarget(pair.Value, binding); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("cannot assign to %s", target)
+	}
+}
+
+// isOptionalChain reports whether a selector, index, slice or call chain
+// contains an optional selector like a?.b.
+func isOptionalChain(expr Expression) bool {
+	for {
+		switch e := expr.(type) {
+		case *SelectorExpression:
+			if e.Optional {
+				return true
+			}
+			expr = e.Left
+		case *IndexExpression:
+			expr = e.Left
+		case *SliceExpression:
+			expr = e.Left
+		case *CallExpression:
+			expr = e.Function
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package ast
+
+// Inspect traverses the tree rooted at node in depth-first order, calling f
+// with each node. It does not descend into the children of a node for which
+// f returns false.
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || !f(node) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		inspectStatements(n.Statements, f)
+	case *BlockStatement:
+		inspectStatements(n.Statements, f)
+	case *ReturnStatement:
+		Inspect(n.ReturnValue, f)
+	case *YieldStatement:
+		Inspect(n.Value, f)
+	case *ExpressionStatement:
+		Inspect(n.Expression, f)
+	case *PrefixExpression:
+		Inspect(n.Right, f)
+	case *InfixExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *AssignExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *CompoundAssignExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *IfExpression:
+		Inspect(n.Condition, f)
+		Inspect(n.Consequence, f)
+		if n.Alternative != nil {
+			Inspect(n.Alternative, f)
+		}
+	case *ForExpression:
+		Inspect(n.Initializer, f)
+		Inspect(n.Condition, f)
+		Inspect(n.Counter, f)
+		Inspect(n.Consequence, f)
+	case *ForInExpression:
+		Inspect(n.Variable, f)
+		Inspect(n.Iterable, f)
+		Inspect(n.Consequence, f)
+	case *StructDeclaration:
+		Inspect(n.Name, f)
+		for _, field := range n.Fields {
+			Inspect(field.Name, f)
+			Inspect(field.Default, f)
+		}
+		for _, method := range n.Methods {
+			Inspect(method.Name, f)
+			Inspect(method.Function, f)
+		}
+	case *FunctionLiteral:
+		for _, param := range n.Parameters {
+			Inspect(param, f)
+		}
+		Inspect(n.Body, f)
+	case *CallExpression:
+		Inspect(n.Function, f)
+		inspectExpressions(n.Arguments, f)
+	case *ArrayLiteral:
+		inspectExpressions(n.Elements, f)
+	case *TupleLiteral:
+		inspectExpressions(n.Elements, f)
+	case *SetLiteral:
+		inspectExpressions(n.Elements, f)
+	case *HashLiteral:
+		for _, pair := range n.Pairs {
+			Inspect(pair.Key, f)
+			Inspect(pair.Value, f)
+		}
+	case *IndexExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Index, f)
+	case *SliceExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Start, f)
+		Inspect(n.End, f)
+		Inspect(n.Step, f)
+	case *SpreadElement:
+		Inspect(n.Value, f)
+	case *SelectorExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	}
+}
+
+func inspectStatements(stmts StatementList, f func(Node) bool) {
+	for _, stmt := range stmts {
+		Inspect(stmt, f)
+	}
+}
+
+func inspectExpressions(exprs ExpressionList, f func(Node) bool) {
+	for _, expr := range exprs {
+		Inspect(expr, f)
+	}
+}
@@ -2,12 +2,13 @@ package builtins
 
 import (
 	"math"
+	"math/big"
 
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Abs(args ...object.Object) object.Object {
+func Abs(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"abs", args,
 		typing.ExactArgs(1),
@@ -17,11 +18,11 @@ func Abs(args ...object.Object) object.Object {
 
 	i := args[0]
 	if i.Type() == object.IntegerType {
-		value := i.(*object.Integer).Value
-		if value < 0 {
-			value = value * -1
+		integer := i.(*object.Integer)
+		if integer.Sign() < 0 {
+			return object.NewBigInteger(new(big.Int).Neg(integer.BigValue()))
 		}
-		return &object.Integer{Value: value}
+		return integer
 	} else if i.Type() == object.FloatType {
 		value := i.(*object.Float).Value
 		return &object.Float{Value: math.Abs(value)}
@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Args(args ...object.Object) object.Object {
+func Args(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"args", args,
 		typing.ExactArgs(0),
@@ -8,7 +8,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Assert(args ...object.Object) object.Object {
+func Assert(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"assert", args,
 		typing.ExactArgs(2),
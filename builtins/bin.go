@@ -1,14 +1,11 @@
 package builtins
 
 import (
-	"fmt"
-	"strconv"
-
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Bin(args ...object.Object) object.Object {
+func Bin(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"bin", args,
 		typing.ExactArgs(1),
@@ -18,5 +15,5 @@ func Bin(args ...object.Object) object.Object {
 	}
 
 	i := args[0].(*object.Integer)
-	return &object.String{Value: fmt.Sprintf("0b%s", strconv.FormatInt(i.Value, 2))}
+	return &object.String{Value: formatInteger(i, 2, "0b")}
 }
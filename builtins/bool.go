@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Bool(args ...object.Object) object.Object {
+func Bool(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"bool", args,
 		typing.ExactArgs(1),
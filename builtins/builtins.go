@@ -2,47 +2,129 @@ package builtins
 
 import (
 	"fmt"
+	"math/big"
 	"sort"
 
 	. "github.com/Ars2014/ulang/object"
 )
 
 var Builtins = map[string]*Builtin{
-	"abs":      {Name: "abs", Fn: Abs},
-	"args":     {Name: "args", Fn: Args},
-	"assert":   {Name: "assert", Fn: Assert},
-	"bin":      {Name: "bin", Fn: Bin},
-	"bool":     {Name: "bool", Fn: Bool},
-	"chr":      {Name: "chr", Fn: Chr},
-	"divmod":   {Name: "divmod", Fn: Divmod},
-	"exit":     {Name: "exit", Fn: Exit},
-	"find":     {Name: "find", Fn: Find},
-	"first":    {Name: "first", Fn: First},
-	"float":    {Name: "float", Fn: ToFloat},
-	"hash":     {Name: "hash", Fn: HashOf},
-	"hex":      {Name: "hex", Fn: Hex},
-	"id":       {Name: "id", Fn: IdOf},
-	"input":    {Name: "input", Fn: Input},
-	"int":      {Name: "int", Fn: Int},
-	"join":     {Name: "join", Fn: Join},
-	"last":     {Name: "last", Fn: Last},
-	"len":      {Name: "len", Fn: Len},
-	"lower":    {Name: "lower", Fn: Lower},
-	"max":      {Name: "max", Fn: Max},
-	"min":      {Name: "min", Fn: Min},
-	"oct":      {Name: "oct", Fn: Oct},
-	"ord":      {Name: "ord", Fn: Ord},
-	"pop":      {Name: "pop", Fn: Pop},
-	"pow":      {Name: "pow", Fn: Pow},
-	"print":    {Name: "print", Fn: Print},
-	"push":     {Name: "push", Fn: Push},
-	"rest":     {Name: "rest", Fn: Rest},
-	"reversed": {Name: "reversed", Fn: Reversed},
-	"sorted":   {Name: "sorted", Fn: Sorted},
-	"split":    {Name: "split", Fn: Split},
-	"str":      {Name: "str", Fn: Str},
-	"typeof":   {Name: "typeof", Fn: TypeOf},
-	"upper":    {Name: "upper", Fn: Upper},
+	"abs":                  {Name: "abs", Fn: Abs},
+	"add":                  {Name: "add", Fn: Add},
+	"all":                  {Name: "all", Fn: All},
+	"any":                  {Name: "any", Fn: Any},
+	"append_file":          {Name: "append_file", Fn: AppendFile},
+	"args":                 {Name: "args", Fn: Args},
+	"assert":               {Name: "assert", Fn: Assert},
+	"at_exit":              {Name: "at_exit", Fn: AtExit},
+	"bin":                  {Name: "bin", Fn: Bin},
+	"bool":                 {Name: "bool", Fn: Bool},
+	"channel":              {Name: "channel", Fn: ToChannel},
+	"chdir":                {Name: "chdir", Fn: Chdir},
+	"choice":               {Name: "choice", Fn: Choice},
+	"chr":                  {Name: "chr", Fn: Chr},
+	"close":                {Name: "close", Fn: Close},
+	"cwd":                  {Name: "cwd", Fn: Cwd},
+	"delete":               {Name: "delete", Fn: Delete},
+	"difference":           {Name: "difference", Fn: Difference},
+	"discard":              {Name: "discard", Fn: Discard},
+	"divmod":               {Name: "divmod", Fn: Divmod},
+	"enumerate":            {Name: "enumerate", Fn: Enumerate},
+	"environ":              {Name: "environ", Fn: Environ},
+	"exec":                 {Name: "exec", Fn: Exec},
+	"exists":               {Name: "exists", Fn: Exists},
+	"exit":                 {Name: "exit", Fn: Exit},
+	"filter":               {Name: "filter", Fn: Filter},
+	"find":                 {Name: "find", Fn: Find},
+	"first":                {Name: "first", Fn: First},
+	"flat_map":             {Name: "flat_map", Fn: FlatMap},
+	"float":                {Name: "float", Fn: ToFloat},
+	"get":                  {Name: "get", Fn: Get},
+	"getenv":               {Name: "getenv", Fn: GetEnv},
+	"group_by":             {Name: "group_by", Fn: GroupBy},
+	"has":                  {Name: "has", Fn: Has},
+	"hash":                 {Name: "hash", Fn: HashOf},
+	"hex":                  {Name: "hex", Fn: Hex},
+	"id":                   {Name: "id", Fn: IdOf},
+	"input":                {Name: "input", Fn: Input},
+	"int":                  {Name: "int", Fn: Int},
+	"intersection":         {Name: "intersection", Fn: Intersection},
+	"items":                {Name: "items", Fn: Items},
+	"iter":                 {Name: "iter", Fn: ToIter},
+	"join":                 {Name: "join", Fn: Join},
+	"json_decode":          {Name: "json_decode", Fn: JsonDecode},
+	"json_encode":          {Name: "json_encode", Fn: JsonEncode},
+	"keys":                 {Name: "keys", Fn: Keys},
+	"last":                 {Name: "last", Fn: Last},
+	"len":                  {Name: "len", Fn: Len},
+	"list":                 {Name: "list", Fn: ToList},
+	"listdir":              {Name: "listdir", Fn: ListDir},
+	"lock":                 {Name: "lock", Fn: Lock},
+	"lower":                {Name: "lower", Fn: Lower},
+	"map":                  {Name: "map", Fn: Map},
+	"max":                  {Name: "max", Fn: Max},
+	"merge":                {Name: "merge", Fn: Merge},
+	"min":                  {Name: "min", Fn: Min},
+	"mkdir":                {Name: "mkdir", Fn: Mkdir},
+	"mutex":                {Name: "mutex", Fn: ToMutex},
+	"next":                 {Name: "next", Fn: Next},
+	"oct":                  {Name: "oct", Fn: Oct},
+	"open":                 {Name: "open", Fn: Open},
+	"ord":                  {Name: "ord", Fn: Ord},
+	"pop":                  {Name: "pop", Fn: Pop},
+	"pow":                  {Name: "pow", Fn: Pow},
+	"print":                {Name: "print", Fn: Print},
+	"push":                 {Name: "push", Fn: Push},
+	"randint":              {Name: "randint", Fn: RandInt},
+	"random":               {Name: "random", Fn: Random},
+	"range":                {Name: "range", Fn: ToRange},
+	"re_compile":           {Name: "re_compile", Fn: ReCompile},
+	"re_find_all":          {Name: "re_find_all", Fn: ReFindAll},
+	"re_match":             {Name: "re_match", Fn: ReMatch},
+	"re_replace":           {Name: "re_replace", Fn: ReReplace},
+	"re_split":             {Name: "re_split", Fn: ReSplit},
+	"read_file":            {Name: "read_file", Fn: ReadFile},
+	"readline":             {Name: "readline", Fn: ReadLine},
+	"recv":                 {Name: "recv", Fn: Recv},
+	"reduce":               {Name: "reduce", Fn: Reduce},
+	"remove":               {Name: "remove", Fn: Remove},
+	"rest":                 {Name: "rest", Fn: Rest},
+	"reversed":             {Name: "reversed", Fn: Reversed},
+	"sample":               {Name: "sample", Fn: Sample},
+	"seed":                 {Name: "seed", Fn: Seed},
+	"select":               {Name: "select", Fn: Select},
+	"send":                 {Name: "send", Fn: Send},
+	"set":                  {Name: "set", Fn: ToSet},
+	"setdefault":           {Name: "setdefault", Fn: SetDefault},
+	"setenv":               {Name: "setenv", Fn: SetEnv},
+	"shuffle":              {Name: "shuffle", Fn: Shuffle},
+	"sorted":               {Name: "sorted", Fn: Sorted},
+	"spawn":                {Name: "spawn", Fn: Spawn},
+	"split":                {Name: "split", Fn: Split},
+	"stat":                 {Name: "stat", Fn: Stat},
+	"str":                  {Name: "str", Fn: Str},
+	"sum":                  {Name: "sum", Fn: Sum},
+	"symmetric_difference": {Name: "symmetric_difference", Fn: SymmetricDifference},
+	"tuple":                {Name: "tuple", Fn: ToTuple},
+	"typeof":               {Name: "typeof", Fn: TypeOf},
+	"union":                {Name: "union", Fn: Union},
+	"unlock":               {Name: "unlock", Fn: Unlock},
+	"update":               {Name: "update", Fn: Update},
+	"upper":                {Name: "upper", Fn: Upper},
+	"values":               {Name: "values", Fn: Values},
+	"wait":                 {Name: "wait", Fn: Wait},
+	"write":                {Name: "write", Fn: Write},
+	"write_file":           {Name: "write_file", Fn: WriteFile},
+	"zip":                  {Name: "zip", Fn: Zip},
+}
+
+// Modules are namespaces of builtins resolved by name like builtins
+// themselves, e.g. math.sqrt. Each interpreter resolves a name to its own
+// copy of the module.
+var Modules = map[string]*Hash{
+	"http": HTTPModule,
+	"math": MathModule,
+	"time": TimeModule,
 }
 
 var BuiltinsIndex []*Builtin
@@ -62,3 +144,76 @@ func init() {
 func newError(format string, a ...interface{}) *Error {
 	return &Error{Message: fmt.Sprintf(format, a...)}
 }
+
+// callMethod calls the named protocol method of obj if it is an instance
+// which declares it.
+func callMethod(env *Environment, obj Object, name string, args ...Object) (Object, bool) {
+	instance, ok := obj.(*Instance)
+	if !ok {
+		return nil, false
+	}
+	method, ok := instance.Method(name)
+	if !ok {
+		return nil, false
+	}
+	return Apply(env, method, args), true
+}
+
+// iterableOf returns obj as an Iterable, calling its __iter__ method if it
+// is an instance which declares one.
+func iterableOf(env *Environment, name string, obj Object) (Iterable, *Error) {
+	if result, ok := callMethod(env, obj, "__iter__"); ok {
+		if err, ok := result.(*Error); ok {
+			return nil, err
+		}
+		obj = result
+	}
+
+	iterable, ok := obj.(Iterable)
+	if !ok {
+		return nil, newError("TypeError: %s() expected an iterable got `%s`", name, obj.Type())
+	}
+	return iterable, nil
+}
+
+// toString returns the string form of obj, calling its __str__ method if it
+// is an instance which declares one.
+func toString(env *Environment, name string, obj Object) (string, *Error) {
+	result, ok := callMethod(env, obj, "__str__")
+	if !ok {
+		return obj.String(), nil
+	}
+
+	switch result := result.(type) {
+	case *Error:
+		return "", result
+	case *String:
+		return result.Value, nil
+	default:
+		return "", newError("TypeError: %s() __str__ returned `%s` instead of `str`", name, result.Type())
+	}
+}
+
+// newHash returns a hash with string keys in sorted order.
+func newHash(members map[string]Object) *Hash {
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := NewHash()
+	for _, name := range names {
+		hash.Set(&String{Value: name}, members[name])
+	}
+	return hash
+}
+
+// formatInteger formats i in the given base, placing the sign in front of
+// the prefix as in -0xff.
+func formatInteger(i *Integer, base int, prefix string) string {
+	if i.Sign() < 0 {
+		return "-" + prefix + new(big.Int).Neg(i.BigValue()).Text(base)
+	}
+	return prefix + i.Text(base)
+}
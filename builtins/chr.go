@@ -2,12 +2,13 @@ package builtins
 
 import (
 	"fmt"
+	"unicode"
 
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Chr(args ...object.Object) object.Object {
+func Chr(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"chr", args,
 		typing.ExactArgs(1),
@@ -17,5 +18,8 @@ func Chr(args ...object.Object) object.Object {
 	}
 
 	i := args[0].(*object.Integer)
+	if i.IsBig() || i.Value < 0 || i.Value > unicode.MaxRune {
+		return newError("ValueError: chr() arg not in range(0x110000)")
+	}
 	return &object.String{Value: fmt.Sprintf("%c", rune(i.Value))}
 }
@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Divmod(args ...object.Object) object.Object {
+func Divmod(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"divmod", args,
 		typing.ExactArgs(2),
@@ -15,9 +15,14 @@ func Divmod(args ...object.Object) object.Object {
 	}
 
 	a := args[0].(*object.Integer)
-	b := args[0].(*object.Integer)
+	b := args[1].(*object.Integer)
+	if b.Sign() == 0 {
+		return newError("ZeroDivisionError: integer division or modulo by zero")
+	}
+
+	quo, rem := a.FloorDivMod(b)
 	elements := make([]object.Object, 2)
-	elements[0] = &object.Integer{Value: a.Value / b.Value}
-	elements[1] = &object.Integer{Value: a.Value % b.Value}
+	elements[0] = quo
+	elements[1] = rem
 	return &object.Array{Elements: elements}
 }
@@ -1,11 +1,14 @@
 package builtins
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Exit(args ...object.Object) object.Object {
+func Exit(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"exit", args,
 		typing.RangeOfArgs(0, 1),
@@ -16,9 +19,16 @@ func Exit(args ...object.Object) object.Object {
 
 	var status int
 	if len(args) == 1 {
-		status = int(args[0].(*object.Integer).Value)
+		code := args[0].(*object.Integer)
+		if code.IsBig() {
+			return newError("OverflowError: exit status too large: %s", code.Inspect())
+		}
+		status = int(code.Value)
 	}
 
+	if err := env.RunExitHooks(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Inspect())
+	}
 	object.ExitFn(status)
 	return nil
 }
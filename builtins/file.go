@@ -0,0 +1,142 @@
+package builtins
+
+import (
+	"os"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+var openFlags = map[string]int{
+	"r": os.O_RDONLY,
+	"w": os.O_WRONLY | os.O_CREATE | os.O_TRUNC,
+	"a": os.O_WRONLY | os.O_CREATE | os.O_APPEND,
+}
+
+func Open(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"open", args,
+		typing.RangeOfArgs(1, 2),
+		typing.WithTypes(object.StringType, object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	name := args[0].(*object.String).Value
+	mode := "r"
+	if len(args) == 2 {
+		mode = args[1].(*object.String).Value
+	}
+
+	flag, ok := openFlags[mode]
+	if !ok {
+		return newError("ValueError: open() invalid mode %q", mode)
+	}
+
+	path, err := resolvePath("open", name)
+	if err != nil {
+		return err
+	}
+
+	handle, e := os.OpenFile(path, flag, 0o644)
+	if e != nil {
+		return ioError(e)
+	}
+	return object.NewFile(name, mode, handle)
+}
+
+// openFile checks the file argument of readline(), write() and close().
+func openFile(name string, args []object.Object) (*object.File, *object.Error) {
+	file := args[0].(*object.File)
+	if file.Closed() {
+		return nil, newError("ValueError: %s() I/O operation on closed file", name)
+	}
+	return file, nil
+}
+
+func ReadLine(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"readline", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.FileType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	file, err := openFile("readline", args)
+	if err != nil {
+		return err
+	}
+	if file.Mode != "r" {
+		return newError("IOError: readline() file %q not open for reading", file.Name)
+	}
+
+	line, ok, e := file.ReadLine()
+	if e != nil {
+		return ioError(e)
+	}
+	if !ok {
+		return nil
+	}
+	return &object.String{Value: line}
+}
+
+func Write(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"write", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.FileType, object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	file, err := openFile("write", args)
+	if err != nil {
+		return err
+	}
+	if file.Mode == "r" {
+		return newError("IOError: write() file %q not open for writing", file.Name)
+	}
+
+	n, e := file.Write(args[1].(*object.String).Value)
+	if e != nil {
+		return ioError(e)
+	}
+	return object.NewInteger(int64(n))
+}
+
+func Close(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"close", args,
+		typing.ExactArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	switch arg := args[0].(type) {
+	case *object.Iter:
+		// close(gen) stops a generator which is not consumed to the end.
+		arg.Close()
+		return nil
+	case *object.Channel:
+		if !arg.Close() {
+			return newError("ValueError: close() of closed channel")
+		}
+		return nil
+	}
+	if err := typing.Check(
+		"close", args,
+		typing.WithTypes(object.FileType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	file := args[0].(*object.File)
+	if file.Closed() {
+		return nil
+	}
+	if e := file.Close(); e != nil {
+		return ioError(e)
+	}
+	return nil
+}
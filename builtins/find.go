@@ -1,14 +1,14 @@
 package builtins
 
 import (
-	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Find(args ...object.Object) object.Object {
+func Find(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"find", args,
 		typing.ExactArgs(2),
@@ -27,17 +27,23 @@ func Find(args ...object.Object) object.Object {
 
 		needle := args[1].(*object.String)
 		index := strings.Index(haystack.Value, needle.Value)
+		if index > 0 {
+			// Strings are indexed by runes.
+			index = utf8.RuneCountInString(haystack.Value[:index])
+		}
 		return &object.Integer{Value: int64(index)}
 	}
 
 	// find([1, 2, 3], 2)
 	if haystack, ok := args[0].(*object.Array); ok {
-		needle := args[1].(object.Comparable)
-		i := sort.Search(len(haystack.Elements), func(i int) bool {
-			return needle.Compare(haystack.Elements[i]) == 0
-		})
-		if i < len(haystack.Elements) && needle.Compare(haystack.Elements[i]) == 0 {
-			return &object.Integer{Value: int64(i)}
+		needle, ok := args[1].(object.Comparable)
+		if !ok {
+			return newError("TypeError: find() expected argument #2 to be comparable got `%s`", args[1].Type())
+		}
+		for i, el := range haystack.Elements {
+			if needle.Compare(el) == 0 {
+				return &object.Integer{Value: int64(i)}
+			}
 		}
 		return &object.Integer{Value: -1}
 	}
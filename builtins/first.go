@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func First(args ...object.Object) object.Object {
+func First(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"first", args,
 		typing.ExactArgs(1),
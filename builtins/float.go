@@ -7,7 +7,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func ToFloat(args ...object.Object) object.Object {
+func ToFloat(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"float", args,
 		typing.ExactArgs(1),
@@ -19,7 +19,7 @@ func ToFloat(args ...object.Object) object.Object {
 	case *object.Boolean:
 		return &object.Float{Value: float64(arg.Int())}
 	case *object.Integer:
-		return &object.Float{Value: float64(arg.Value)}
+		return &object.Float{Value: arg.Float64()}
 	case *object.Float:
 		return arg
 	case *object.String:
@@ -0,0 +1,277 @@
+package builtins
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// realPath resolves symlinks in path. Trailing components which do not
+// exist yet are kept as is, so the result can be used to create files.
+func realPath(path string) (string, error) {
+	var rest []string
+	for {
+		if _, err := os.Lstat(path); err == nil {
+			real, err := filepath.EvalSymlinks(path)
+			if err != nil {
+				return "", err
+			}
+			return filepath.Join(append([]string{real}, rest...)...), nil
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return filepath.Join(append([]string{path}, rest...)...), nil
+		}
+		rest = append([]string{filepath.Base(path)}, rest...)
+		path = parent
+	}
+}
+
+func withinRoot(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// resolvePath returns the real location of path after checking that it does
+// not leave the roots configured in object.Roots.
+func resolvePath(name, path string) (string, *object.Error) {
+	if len(object.Roots) == 0 {
+		return "", newError("PermissionError: %s() file system access is disabled", name)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", ioError(err)
+	}
+	real, err := realPath(abs)
+	if err != nil {
+		return "", ioError(err)
+	}
+
+	for _, root := range object.Roots {
+		root, err := filepath.Abs(root)
+		if err != nil {
+			continue
+		}
+		if root, err = filepath.EvalSymlinks(root); err != nil {
+			continue
+		}
+		if withinRoot(root, real) {
+			return real, nil
+		}
+	}
+
+	return "", newError("PermissionError: %s() path %q is outside of the allowed roots", name, path)
+}
+
+func isRoot(path string) bool {
+	for _, root := range object.Roots {
+		if root, err := filepath.Abs(root); err == nil {
+			if root, err = filepath.EvalSymlinks(root); err == nil && root == path {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ioError(err error) *object.Error {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return newError("FileNotFoundError: %s", err)
+	case errors.Is(err, os.ErrExist):
+		return newError("FileExistsError: %s", err)
+	case errors.Is(err, os.ErrPermission):
+		return newError("PermissionError: %s", err)
+	default:
+		return newError("IOError: %s", err)
+	}
+}
+
+func ReadFile(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"read_file", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	path, err := resolvePath("read_file", args[0].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	data, e := os.ReadFile(path)
+	if e != nil {
+		return ioError(e)
+	}
+	return &object.String{Value: string(data)}
+}
+
+func writeFile(name string, flag int, args []object.Object) object.Object {
+	if err := typing.Check(
+		name, args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.StringType, object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	path, err := resolvePath(name, args[0].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	f, e := os.OpenFile(path, flag, 0o644)
+	if e != nil {
+		return ioError(e)
+	}
+	if _, e = f.WriteString(args[1].(*object.String).Value); e != nil {
+		_ = f.Close()
+		return ioError(e)
+	}
+	if e = f.Close(); e != nil {
+		return ioError(e)
+	}
+	return nil
+}
+
+func WriteFile(env *object.Environment, args ...object.Object) object.Object {
+	return writeFile("write_file", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, args)
+}
+
+func AppendFile(env *object.Environment, args ...object.Object) object.Object {
+	return writeFile("append_file", os.O_WRONLY|os.O_CREATE|os.O_APPEND, args)
+}
+
+func Exists(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"exists", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	path, err := resolvePath("exists", args[0].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	_, e := os.Stat(path)
+	switch {
+	case e == nil:
+		return &object.Boolean{Value: true}
+	case errors.Is(e, os.ErrNotExist):
+		return &object.Boolean{Value: false}
+	default:
+		return ioError(e)
+	}
+}
+
+func ListDir(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"listdir", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	path, err := resolvePath("listdir", args[0].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	entries, e := os.ReadDir(path)
+	if e != nil {
+		return ioError(e)
+	}
+
+	names := make([]object.Object, len(entries))
+	for i, entry := range entries {
+		names[i] = &object.String{Value: entry.Name()}
+	}
+	return &object.Array{Elements: names}
+}
+
+func Mkdir(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"mkdir", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	path, err := resolvePath("mkdir", args[0].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	if e := os.MkdirAll(path, 0o755); e != nil {
+		return ioError(e)
+	}
+	return nil
+}
+
+// Remove removes a file or an empty directory.
+func Remove(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"remove", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	path, err := resolvePath("remove", args[0].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+	if isRoot(path) {
+		return newError("PermissionError: remove() cannot remove root %q", args[0].(*object.String).Value)
+	}
+
+	if e := os.Remove(path); e != nil {
+		return ioError(e)
+	}
+	return nil
+}
+
+func Stat(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"stat", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	path, err := resolvePath("stat", args[0].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+
+	info, e := os.Stat(path)
+	if e != nil {
+		return ioError(e)
+	}
+
+	return newHash(map[string]object.Object{
+		"name":   &object.String{Value: info.Name()},
+		"size":   object.NewInteger(info.Size()),
+		"mode":   &object.String{Value: info.Mode().String()},
+		"is_dir": &object.Boolean{Value: info.IsDir()},
+		"mtime":  object.NewInteger(info.ModTime().Unix()),
+	})
+}
@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func HashOf(args ...object.Object) object.Object {
+func HashOf(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"hash", args,
 		typing.ExactArgs(1),
@@ -13,8 +13,8 @@ func HashOf(args ...object.Object) object.Object {
 		return newError(err.Error())
 	}
 
-	if hash, ok := args[0].(object.Hashable); ok {
-		return &object.Integer{Value: int64(hash.HashKey().Value)}
+	if object.IsHashable(args[0]) {
+		return &object.Integer{Value: int64(args[0].(object.Hashable).HashKey().Value)}
 	}
 
 	return newError("TypeError: hash() expected argument #1 to be hashable")
@@ -0,0 +1,216 @@
+package builtins
+
+import (
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// checkKey reports an error unless key can be stored in a hash.
+func checkKey(name string, key object.Object) *object.Error {
+	if !object.IsHashable(key) {
+		return newError("TypeError: %s() unusable as hash key: `%s`", name, key.Type())
+	}
+	return nil
+}
+
+// checkHashes reports an error unless all of args are hashes.
+func checkHashes(name string, args []object.Object) *object.Error {
+	for i, arg := range args {
+		if _, ok := arg.(*object.Hash); !ok {
+			return newError("TypeError: %s() expected argument #%d to be `hash` got `%s`", name, i+1, arg.Type())
+		}
+	}
+	return nil
+}
+
+// Keys returns the keys of a hash in insertion order.
+func Keys(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"keys", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	pairs := args[0].(*object.Hash).Pairs()
+	keys := make([]object.Object, len(pairs))
+	for i, pair := range pairs {
+		keys[i] = pair.Key
+	}
+	return &object.Array{Elements: keys}
+}
+
+// Values returns the values of a hash in insertion order.
+func Values(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"values", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	pairs := args[0].(*object.Hash).Pairs()
+	values := make([]object.Object, len(pairs))
+	for i, pair := range pairs {
+		values[i] = pair.Value
+	}
+	return &object.Array{Elements: values}
+}
+
+// Items returns the pairs of a hash as [key, value] arrays in insertion
+// order.
+func Items(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"items", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	pairs := args[0].(*object.Hash).Pairs()
+	items := make([]object.Object, len(pairs))
+	for i, pair := range pairs {
+		items[i] = &object.Array{Elements: []object.Object{pair.Key, pair.Value}}
+	}
+	return &object.Array{Elements: items}
+}
+
+// Delete removes a key from a hash and reports whether it was present.
+func Delete(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"delete", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkKey("delete", args[1]); err != nil {
+		return err
+	}
+
+	return &object.Boolean{Value: args[0].(*object.Hash).Delete(args[1])}
+}
+
+// Has reports whether a hash has a key or a set has an element.
+func Has(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"has", args,
+		typing.ExactArgs(2),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	// has({1, 2}, 1)
+	if set, ok := args[0].(*object.Set); ok {
+		if err := checkElement(args[1]); err != nil {
+			return err
+		}
+		return &object.Boolean{Value: set.Has(args[1])}
+	}
+
+	// has({"a": 1}, "a")
+	if err := typing.Check(
+		"has", args,
+		typing.WithTypes(object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkKey("has", args[1]); err != nil {
+		return err
+	}
+
+	_, ok := args[0].(*object.Hash).Get(args[1])
+	return &object.Boolean{Value: ok}
+}
+
+// Get returns the value stored for a key, or the default value (null unless
+// given) when the key is missing.
+func Get(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"get", args,
+		typing.RangeOfArgs(2, 3),
+		typing.WithTypes(object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkKey("get", args[1]); err != nil {
+		return err
+	}
+
+	if value, ok := args[0].(*object.Hash).Get(args[1]); ok {
+		return value
+	}
+	if len(args) == 3 {
+		return args[2]
+	}
+	return nil
+}
+
+// Update adds the pairs of the other hashes to the first one in place and
+// returns it. Later hashes win on duplicate keys.
+func Update(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"update", args,
+		typing.MinimumArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkHashes("update", args); err != nil {
+		return err
+	}
+
+	hash := args[0].(*object.Hash)
+	for _, arg := range args[1:] {
+		for _, pair := range arg.(*object.Hash).Pairs() {
+			hash.Set(pair.Key, pair.Value)
+		}
+	}
+	return hash
+}
+
+// Merge is like Update but returns a new hash, leaving its arguments
+// unchanged.
+func Merge(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"merge", args,
+		typing.MinimumArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkHashes("merge", args); err != nil {
+		return err
+	}
+
+	hash := args[0].(*object.Hash).Copy()
+	for _, arg := range args[1:] {
+		for _, pair := range arg.(*object.Hash).Pairs() {
+			hash.Set(pair.Key, pair.Value)
+		}
+	}
+	return hash
+}
+
+// SetDefault returns the value stored for a key, storing the default value
+// first when the key is missing.
+func SetDefault(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"setdefault", args,
+		typing.ExactArgs(3),
+		typing.WithTypes(object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkKey("setdefault", args[1]); err != nil {
+		return err
+	}
+
+	hash := args[0].(*object.Hash)
+	if value, ok := hash.Get(args[1]); ok {
+		return value
+	}
+	hash.Set(args[1], args[2])
+	return args[2]
+}
@@ -1,14 +1,13 @@
 package builtins
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Hex(args ...object.Object) object.Object {
+func Hex(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"hex", args,
 		typing.ExactArgs(1),
@@ -19,7 +18,7 @@ func Hex(args ...object.Object) object.Object {
 	i := args[0]
 	switch i.Type() {
 	case object.IntegerType:
-		return &object.String{Value: fmt.Sprintf("0x%s", strconv.FormatInt(i.(*object.Integer).Value, 16))}
+		return &object.String{Value: formatInteger(i.(*object.Integer), 16, "0x")}
 	case object.FloatType:
 		return &object.String{Value: strconv.FormatFloat(i.(*object.Float).Value, 'x', -1, 64)}
 	default:
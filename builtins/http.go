@@ -0,0 +1,285 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// defaultHTTPTimeout applies to requests made without a timeout option.
+const defaultHTTPTimeout = 30 * time.Second
+
+var HTTPModule = newHash(map[string]object.Object{
+	"get":     &object.Builtin{Name: "http.get", Fn: HTTPGet},
+	"post":    &object.Builtin{Name: "http.post", Fn: HTTPPost},
+	"request": &object.Builtin{Name: "http.request", Fn: HTTPRequest},
+	"serve":   &object.Builtin{Name: "http.serve", Fn: HTTPServe},
+})
+
+// headersHash converts headers to a hash, joining repeated values with ", ".
+func headersHash(header http.Header) *object.Hash {
+	headers := make(map[string]object.Object, len(header))
+	for name, values := range header {
+		headers[name] = &object.String{Value: strings.Join(values, ", ")}
+	}
+	return newHash(headers)
+}
+
+func setHeaders(name string, header http.Header, obj object.Object) *object.Error {
+	hash, ok := obj.(*object.Hash)
+	if !ok {
+		return newError("TypeError: %s() expected headers to be `hash` got `%s`", name, obj.Type())
+	}
+
+	for _, pair := range hash.Pairs() {
+		key, ok := pair.Key.(*object.String)
+		if !ok {
+			return newError("TypeError: %s() header names must be `str` got `%s`", name, pair.Key.Type())
+		}
+		header.Set(key.Value, pair.Value.String())
+	}
+	return nil
+}
+
+// doRequest performs a request with the options headers, body and timeout.
+func doRequest(env *object.Environment, name, method, url string, options object.Object) object.Object {
+	var body io.Reader
+	header := make(http.Header)
+	timeout := defaultHTTPTimeout
+
+	if options != nil {
+		hash, ok := options.(*object.Hash)
+		if !ok {
+			return newError("TypeError: %s() expected options to be `hash` got `%s`", name, options.Type())
+		}
+
+		for _, pair := range hash.Pairs() {
+			key, ok := pair.Key.(*object.String)
+			if !ok {
+				return newError("TypeError: %s() option names must be `str` got `%s`", name, pair.Key.Type())
+			}
+
+			var err *object.Error
+			switch key.Value {
+			case "headers":
+				err = setHeaders(name, header, pair.Value)
+			case "body":
+				body = strings.NewReader(pair.Value.String())
+			case "timeout":
+				switch pair.Value.(type) {
+				case *object.Integer, *object.Float, *object.Duration:
+					timeout, err = toTimeout(name, pair.Value)
+				default:
+					err = newError("TypeError: %s() invalid type `%s` for option \"timeout\"", name, pair.Value.Type())
+				}
+			default:
+				err = newError("ValueError: %s() unknown option %q", name, key.Value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return newError("ValueError: %s() %s", name, err)
+	}
+	req.Header = header
+
+	var (
+		resp *http.Response
+		data []byte
+	)
+	env.Block(func() {
+		client := &http.Client{Timeout: timeout}
+		resp, err = client.Do(req)
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+		data, err = io.ReadAll(resp.Body)
+	})
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return newError("TimeoutError: %s() request timed out after %s", name, timeout)
+		}
+		return newError("IOError: %s() %s", name, err)
+	}
+
+	return newHash(map[string]object.Object{
+		"status":  object.NewInteger(int64(resp.StatusCode)),
+		"headers": headersHash(resp.Header),
+		"body":    &object.String{Value: string(data)},
+	})
+}
+
+func HTTPGet(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"http.get", args,
+		typing.RangeOfArgs(1, 2),
+		typing.WithTypes(object.StringType, object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	var options object.Object
+	if len(args) == 2 {
+		options = args[1]
+	}
+	return doRequest(env, "http.get", http.MethodGet, args[0].(*object.String).Value, options)
+}
+
+// HTTPPost sends body, which is added to the other options.
+func HTTPPost(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"http.post", args,
+		typing.RangeOfArgs(2, 3),
+		typing.WithTypes(object.StringType, object.StringType, object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	options := object.NewHash()
+	if len(args) == 3 {
+		options = args[2].(*object.Hash).Copy()
+	}
+	options.Set(&object.String{Value: "body"}, args[1])
+
+	return doRequest(env, "http.post", http.MethodPost, args[0].(*object.String).Value, options)
+}
+
+func HTTPRequest(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"http.request", args,
+		typing.RangeOfArgs(2, 3),
+		typing.WithTypes(object.StringType, object.StringType, object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	var options object.Object
+	if len(args) == 3 {
+		options = args[2]
+	}
+	method := strings.ToUpper(args[0].(*object.String).Value)
+	return doRequest(env, "http.request", method, args[1].(*object.String).Value, options)
+}
+
+// HTTPHandler returns a handler which calls fn from env with a hash
+// describing each request. Calls are serialized, also with the tasks of the
+// interpreter. fn may return the body as `str`, null for an empty response,
+// or a hash with status, headers and body.
+func HTTPHandler(env *object.Environment, fn object.Object) http.Handler {
+	var mu sync.Mutex
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		query := make(map[string]object.Object)
+		for name, values := range r.URL.Query() {
+			query[name] = &object.String{Value: values[0]}
+		}
+		request := newHash(map[string]object.Object{
+			"method":  &object.String{Value: r.Method},
+			"path":    &object.String{Value: r.URL.Path},
+			"query":   newHash(query),
+			"headers": headersHash(r.Header),
+			"body":    &object.String{Value: string(data)},
+		})
+
+		result := func() (result object.Object) {
+			mu.Lock()
+			defer mu.Unlock()
+			env.Enter(func() {
+				defer func() {
+					if r := recover(); r != nil {
+						result = newError("InternalError: %v", r)
+					}
+				}()
+				result = object.Apply(env, fn, []object.Object{request})
+			})
+			return result
+		}()
+
+		if err := writeResponse(w, result); err != nil {
+			fmt.Fprintln(os.Stderr, err.Inspect())
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	})
+}
+
+func writeResponse(w http.ResponseWriter, result object.Object) *object.Error {
+	switch result := result.(type) {
+	case *object.Error:
+		return result
+	case *object.Null:
+		w.WriteHeader(http.StatusNoContent)
+	case *object.String:
+		_, _ = io.WriteString(w, result.Value)
+	case *object.Hash:
+		status := http.StatusOK
+		var body string
+		for _, pair := range result.Pairs() {
+			switch pair.Key.String() {
+			case "status":
+				code, ok := pair.Value.(*object.Integer)
+				if !ok || code.IsBig() || code.Value < 100 || code.Value > 999 {
+					return newError("ValueError: http.serve() invalid status %s", pair.Value.Inspect())
+				}
+				status = int(code.Value)
+			case "headers":
+				if err := setHeaders("http.serve", w.Header(), pair.Value); err != nil {
+					return err
+				}
+			case "body":
+				body = pair.Value.String()
+			default:
+				return newError("ValueError: http.serve() unknown response field %s", pair.Key.Inspect())
+			}
+		}
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	default:
+		return newError("TypeError: http.serve() handler must return `str`, `hash` or null got `%s`", result.Type())
+	}
+	return nil
+}
+
+// HTTPServe serves HTTP requests on addr with a handler function until the
+// server fails.
+func HTTPServe(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"http.serve", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	if !object.IsCallable(args[1]) {
+		return newError("TypeError: http.serve() expected argument #2 to be `fn` got `%s`", args[1].Type())
+	}
+
+	server := &http.Server{Addr: args[0].(*object.String).Value, Handler: HTTPHandler(env, args[1])}
+	var err error
+	env.Block(func() { err = server.ListenAndServe() })
+	if err != nil {
+		return newError("IOError: http.serve() %s", err)
+	}
+	return nil
+}
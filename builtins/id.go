@@ -7,7 +7,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func IdOf(args ...object.Object) object.Object {
+func IdOf(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"id", args,
 		typing.ExactArgs(1),
@@ -9,7 +9,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Input(args ...object.Object) object.Object {
+func Input(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"input", args,
 		typing.RangeOfArgs(0, 1),
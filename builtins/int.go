@@ -1,13 +1,15 @@
 package builtins
 
 import (
+	"math"
+	"math/big"
 	"strconv"
 
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Int(args ...object.Object) object.Object {
+func Int(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"int", args,
 		typing.ExactArgs(1),
@@ -21,13 +23,20 @@ func Int(args ...object.Object) object.Object {
 	case *object.Integer:
 		return arg
 	case *object.Float:
-		return &object.Integer{Value: int64(arg.Value)}
+		if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+			return newError("ValueError: cannot convert float %s to int", arg.Inspect())
+		}
+		n, _ := big.NewFloat(arg.Value).Int(nil)
+		return object.NewBigInteger(n)
 	case *object.String:
 		n, err := strconv.ParseInt(arg.Value, 0, 64)
-		if err != nil {
-			return newError("could not parse string to int: %s", err)
+		if err == nil {
+			return &object.Integer{Value: n}
+		}
+		if b, ok := new(big.Int).SetString(arg.Value, 0); ok {
+			return object.NewBigInteger(b)
 		}
-		return &object.Integer{Value: n}
+		return newError("could not parse string to int: %s", err)
 	default:
 		return newError("TypeError: cannot cast %s to int", arg.Type())
 	}
@@ -0,0 +1,76 @@
+package builtins
+
+import (
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// ToList returns an array of the elements of an iterable, which consumes it
+// if it is an iterator, or an empty array without arguments.
+func ToList(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"list", args,
+		typing.RangeOfArgs(0, 1),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	elements := []object.Object{}
+	if len(args) == 0 {
+		return &object.Array{Elements: elements}
+	}
+
+	err := each(env, "list", args[0], func(el object.Object) (bool, object.Object) {
+		elements = append(elements, el)
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	return &object.Array{Elements: elements}
+}
+
+// ToIter returns an iterator object over the elements of an iterable, or
+// the iterator object itself.
+func ToIter(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"iter", args,
+		typing.ExactArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	if it, ok := args[0].(*object.Iter); ok {
+		return it
+	}
+	iterable, err := iterableOf(env, "iter", args[0])
+	if err != nil {
+		return err
+	}
+	if it, ok := iterable.(*object.Iter); ok {
+		return it
+	}
+	return object.NewIter("iterator", iterable.Iter().Next, nil)
+}
+
+// Next returns the next element of an iterator object. Once it is exhausted
+// it returns the default if one is given and raises StopIteration if not.
+func Next(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"next", args,
+		typing.RangeOfArgs(1, 2),
+		typing.WithTypes(object.IterType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	el, ok := args[0].(*object.Iter).Next()
+	switch {
+	case ok:
+		return el
+	case len(args) == 2:
+		return args[1]
+	default:
+		return newError("StopIteration: next() iterator is exhausted")
+	}
+}
@@ -0,0 +1,419 @@
+package builtins
+
+import (
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// checkCallable reports an error unless argument #n of a builtin can be
+// called.
+func checkCallable(name string, n int, obj object.Object) *object.Error {
+	if !object.IsCallable(obj) {
+		return newError("TypeError: %s() expected argument #%d to be `fn` got `%s`", name, n, obj.Type())
+	}
+	return nil
+}
+
+// each calls f with the elements of an iterable in order until f returns
+// false or an error, closing the iterator if it stops early.
+func each(env *object.Environment, name string, obj object.Object, f func(el object.Object) (bool, object.Object)) object.Object {
+	iterable, err := iterableOf(env, name, obj)
+	if err != nil {
+		return err
+	}
+
+	iter := iterable.Iter()
+	for {
+		el, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if el.Type() == object.ErrorType {
+			return el
+		}
+		more, result := f(el)
+		if result != nil && result.Type() == object.ErrorType {
+			object.CloseIterator(iter)
+			return result
+		}
+		if !more {
+			object.CloseIterator(iter)
+			return nil
+		}
+	}
+}
+
+// Map returns an array of the results of calling a function with each
+// element of an iterable. Given an iterator object it returns an iterator
+// which calls the function as elements are consumed.
+func Map(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"map", args,
+		typing.ExactArgs(2),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkCallable("map", 2, args[1]); err != nil {
+		return err
+	}
+	if src, ok := args[0].(*object.Iter); ok {
+		return object.NewIter("map", func() (object.Object, bool) {
+			el, ok := src.Next()
+			if !ok || el.Type() == object.ErrorType {
+				return el, ok
+			}
+			return object.Apply(env, args[1], []object.Object{el}), true
+		}, src.Close)
+	}
+
+	elements := []object.Object{}
+	err := each(env, "map", args[0], func(el object.Object) (bool, object.Object) {
+		result := object.Apply(env, args[1], []object.Object{el})
+		elements = append(elements, result)
+		return true, result
+	})
+	if err != nil {
+		return err
+	}
+	return &object.Array{Elements: elements}
+}
+
+// Filter returns an array of the elements of an iterable for which a
+// function returns a truthy value, or an iterator over them given an
+// iterator object.
+func Filter(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"filter", args,
+		typing.ExactArgs(2),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkCallable("filter", 2, args[1]); err != nil {
+		return err
+	}
+	if src, ok := args[0].(*object.Iter); ok {
+		return object.NewIter("filter", func() (object.Object, bool) {
+			for {
+				el, ok := src.Next()
+				if !ok || el.Type() == object.ErrorType {
+					return el, ok
+				}
+				result := object.Apply(env, args[1], []object.Object{el})
+				if result.Type() == object.ErrorType {
+					return result, true
+				}
+				if result.Bool() {
+					return el, true
+				}
+			}
+		}, src.Close)
+	}
+
+	elements := []object.Object{}
+	err := each(env, "filter", args[0], func(el object.Object) (bool, object.Object) {
+		result := object.Apply(env, args[1], []object.Object{el})
+		if result.Bool() {
+			elements = append(elements, el)
+		}
+		return true, result
+	})
+	if err != nil {
+		return err
+	}
+	return &object.Array{Elements: elements}
+}
+
+// Reduce folds the elements of an iterable from the left with a function of
+// the accumulator and an element, starting from the initial value or else
+// the first element.
+func Reduce(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"reduce", args,
+		typing.RangeOfArgs(2, 3),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkCallable("reduce", 2, args[1]); err != nil {
+		return err
+	}
+
+	var acc object.Object
+	if len(args) == 3 {
+		acc = args[2]
+	}
+	err := each(env, "reduce", args[0], func(el object.Object) (bool, object.Object) {
+		if acc == nil {
+			acc = el
+		} else {
+			acc = object.Apply(env, args[1], []object.Object{acc, el})
+		}
+		return true, acc
+	})
+	if err != nil {
+		return err
+	}
+	if acc == nil {
+		return newError("TypeError: reduce() of empty iterable with no initial value")
+	}
+	return acc
+}
+
+// Any reports whether any element of an iterable is truthy. It stops at the
+// first one which is.
+func Any(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"any", args,
+		typing.ExactArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	found := false
+	err := each(env, "any", args[0], func(el object.Object) (bool, object.Object) {
+		found = el.Bool()
+		return !found, nil
+	})
+	if err != nil {
+		return err
+	}
+	return &object.Boolean{Value: found}
+}
+
+// All reports whether every element of an iterable is truthy. It stops at
+// the first one which is not.
+func All(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"all", args,
+		typing.ExactArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	all := true
+	err := each(env, "all", args[0], func(el object.Object) (bool, object.Object) {
+		all = el.Bool()
+		return all, nil
+	})
+	if err != nil {
+		return err
+	}
+	return &object.Boolean{Value: all}
+}
+
+// Sum adds up the elements of an iterable with +, starting from 0 or the
+// given start value.
+func Sum(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"sum", args,
+		typing.RangeOfArgs(1, 2),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	var total object.Object = &object.Integer{Value: 0}
+	if len(args) == 2 {
+		total = args[1]
+	}
+	err := each(env, "sum", args[0], func(el object.Object) (bool, object.Object) {
+		total = object.ApplyOperator(env, "+", total, el)
+		return true, total
+	})
+	if err != nil {
+		return err
+	}
+	return total
+}
+
+// Zip returns an array of tuples of the elements of iterables at the same
+// position. It stops with the shortest iterable. If any of them is an
+// iterator object the tuples are produced lazily by an iterator too.
+func Zip(env *object.Environment, args ...object.Object) object.Object {
+	lazy := false
+	iters := make([]object.Iterator, len(args))
+	for i, arg := range args {
+		iterable, err := iterableOf(env, "zip", arg)
+		if err != nil {
+			return err
+		}
+		iters[i] = iterable.Iter()
+		_, ok := arg.(*object.Iter)
+		lazy = lazy || ok
+	}
+
+	// The iterators left over when the shortest one stops are closed.
+	closeAll := func() {
+		for _, iter := range iters {
+			object.CloseIterator(iter)
+		}
+	}
+	next := func() (object.Object, bool) {
+		if len(iters) == 0 {
+			return nil, false
+		}
+		tuple := &object.Tuple{Elements: make([]object.Object, len(iters))}
+		for i, iter := range iters {
+			el, ok := iter.Next()
+			if !ok || el.Type() == object.ErrorType {
+				closeAll()
+				return el, ok
+			}
+			tuple.Elements[i] = el
+		}
+		return tuple, true
+	}
+	if lazy {
+		return object.NewIter("zip", next, closeAll)
+	}
+
+	elements := []object.Object{}
+	for {
+		tuple, ok := next()
+		if !ok {
+			return &object.Array{Elements: elements}
+		}
+		if tuple.Type() == object.ErrorType {
+			return tuple
+		}
+		elements = append(elements, tuple)
+	}
+}
+
+// Enumerate returns an array of (index, element) tuples of an iterable,
+// counting from 0 or the given start, or an iterator over them given an
+// iterator object.
+func Enumerate(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"enumerate", args,
+		typing.RangeOfArgs(1, 2),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	var index object.Object = &object.Integer{Value: 0}
+	if len(args) == 2 {
+		if _, ok := args[1].(*object.Integer); !ok {
+			return newError("TypeError: enumerate() expected argument #2 to be `int` got `%s`", args[1].Type())
+		}
+		index = args[1]
+	}
+
+	one := &object.Integer{Value: 1}
+	if src, ok := args[0].(*object.Iter); ok {
+		return object.NewIter("enumerate", func() (object.Object, bool) {
+			el, ok := src.Next()
+			if !ok || el.Type() == object.ErrorType {
+				return el, ok
+			}
+			tuple := &object.Tuple{Elements: []object.Object{index, el}}
+			index = object.ApplyOperator(env, "+", index, one)
+			return tuple, true
+		}, src.Close)
+	}
+
+	elements := []object.Object{}
+	err := each(env, "enumerate", args[0], func(el object.Object) (bool, object.Object) {
+		elements = append(elements, &object.Tuple{Elements: []object.Object{index, el}})
+		index = object.ApplyOperator(env, "+", index, one)
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	return &object.Array{Elements: elements}
+}
+
+// FlatMap calls a function with each element of an iterable and returns an
+// array of the elements of the iterables it returns, or an iterator over
+// them given an iterator object.
+func FlatMap(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"flat_map", args,
+		typing.ExactArgs(2),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkCallable("flat_map", 2, args[1]); err != nil {
+		return err
+	}
+	if src, ok := args[0].(*object.Iter); ok {
+		var inner object.Iterator
+		return object.NewIter("flat_map", func() (object.Object, bool) {
+			for {
+				if inner != nil {
+					if el, ok := inner.Next(); ok {
+						return el, true
+					}
+				}
+				el, ok := src.Next()
+				if !ok || el.Type() == object.ErrorType {
+					return el, ok
+				}
+				result := object.Apply(env, args[1], []object.Object{el})
+				if result.Type() == object.ErrorType {
+					return result, true
+				}
+				iterable, err := iterableOf(env, "flat_map", result)
+				if err != nil {
+					return err, true
+				}
+				inner = iterable.Iter()
+			}
+		}, func() {
+			if inner != nil {
+				object.CloseIterator(inner)
+			}
+			src.Close()
+		})
+	}
+
+	elements := []object.Object{}
+	err := each(env, "flat_map", args[0], func(el object.Object) (bool, object.Object) {
+		result := object.Apply(env, args[1], []object.Object{el})
+		if result.Type() == object.ErrorType {
+			return false, result
+		}
+		return true, each(env, "flat_map", result, func(el object.Object) (bool, object.Object) {
+			elements = append(elements, el)
+			return true, nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return &object.Array{Elements: elements}
+}
+
+// GroupBy returns a hash from the results of calling a function with each
+// element of an iterable to arrays of the elements giving that result.
+func GroupBy(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"group_by", args,
+		typing.ExactArgs(2),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkCallable("group_by", 2, args[1]); err != nil {
+		return err
+	}
+
+	groups := object.NewHash()
+	err := each(env, "group_by", args[0], func(el object.Object) (bool, object.Object) {
+		key := object.Apply(env, args[1], []object.Object{el})
+		if key.Type() == object.ErrorType {
+			return false, key
+		}
+		if err := checkKey("group_by", key); err != nil {
+			return false, err
+		}
+		if group, ok := groups.Get(key); ok {
+			group.(*object.Array).Append(el)
+		} else {
+			groups.Set(key, &object.Array{Elements: []object.Object{el}})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	return groups
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Join(args ...object.Object) object.Object {
+func Join(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"join", args,
 		typing.ExactArgs(2),
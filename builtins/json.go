@@ -0,0 +1,311 @@
+package builtins
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// maxJSONDepth limits the nesting of decoded arrays and objects.
+const maxJSONDepth = 10000
+
+type jsonEncoder struct {
+	out    strings.Builder
+	indent string
+	seen   map[object.Object]bool
+}
+
+func (e *jsonEncoder) newline(depth int) {
+	if e.indent != "" {
+		e.out.WriteByte('\n')
+		e.out.WriteString(strings.Repeat(e.indent, depth))
+	}
+}
+
+func (e *jsonEncoder) writeString(s string) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	e.out.Write(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}))
+}
+
+// enter marks a container as being encoded to detect circular references.
+func (e *jsonEncoder) enter(obj object.Object) *object.Error {
+	if e.seen[obj] {
+		return newError("ValueError: json_encode() circular reference detected")
+	}
+	e.seen[obj] = true
+	return nil
+}
+
+func (e *jsonEncoder) encode(obj object.Object, depth int) *object.Error {
+	switch obj := obj.(type) {
+	case *object.Null:
+		e.out.WriteString("null")
+	case *object.Boolean:
+		e.out.WriteString(strconv.FormatBool(obj.Value))
+	case *object.Integer:
+		e.out.WriteString(obj.Inspect())
+	case *object.Float:
+		if math.IsNaN(obj.Value) || math.IsInf(obj.Value, 0) {
+			return newError("ValueError: json_encode() float %s is not JSON compliant", obj.Inspect())
+		}
+		s := strconv.FormatFloat(obj.Value, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		e.out.WriteString(s)
+	case *object.String:
+		e.writeString(obj.Value)
+	case *object.Tuple:
+		return e.encode(&object.Array{Elements: obj.Elements}, depth)
+	case *object.Set:
+		return e.encode(&object.Array{Elements: obj.Elements()}, depth)
+
+	case *object.Array:
+		if err := e.enter(obj); err != nil {
+			return err
+		}
+		defer delete(e.seen, obj)
+
+		if len(obj.Elements) == 0 {
+			e.out.WriteString("[]")
+			return nil
+		}
+		e.out.WriteByte('[')
+		for i, element := range obj.Elements {
+			if i > 0 {
+				e.out.WriteByte(',')
+			}
+			e.newline(depth + 1)
+			if err := e.encode(element, depth+1); err != nil {
+				return err
+			}
+		}
+		e.newline(depth)
+		e.out.WriteByte(']')
+
+	case *object.Hash:
+		if err := e.enter(obj); err != nil {
+			return err
+		}
+		defer delete(e.seen, obj)
+
+		if obj.Len() == 0 {
+			e.out.WriteString("{}")
+			return nil
+		}
+
+		e.out.WriteByte('{')
+		for i, pair := range obj.Pairs() {
+			if pair.Key.Type() != object.StringType {
+				return newError("TypeError: json_encode() keys must be `str` got `%s`", pair.Key.Type())
+			}
+			if i > 0 {
+				e.out.WriteByte(',')
+			}
+			e.newline(depth + 1)
+			e.writeString(pair.Key.(*object.String).Value)
+			e.out.WriteByte(':')
+			if e.indent != "" {
+				e.out.WriteByte(' ')
+			}
+			if err := e.encode(pair.Value, depth+1); err != nil {
+				return err
+			}
+		}
+		e.newline(depth)
+		e.out.WriteByte('}')
+
+	default:
+		return newError("TypeError: json_encode() object of type `%s` is not JSON serializable", obj.Type())
+	}
+
+	return nil
+}
+
+func JsonEncode(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"json_encode", args,
+		typing.RangeOfArgs(1, 2),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	e := &jsonEncoder{seen: make(map[object.Object]bool)}
+	if len(args) == 2 {
+		switch indent := args[1].(type) {
+		case *object.Integer:
+			if indent.IsBig() || indent.Value < 0 || indent.Value > 64 {
+				return newError("ValueError: json_encode() indent must be between 0 and 64 got %s", indent.Inspect())
+			}
+			e.indent = strings.Repeat(" ", int(indent.Value))
+		case *object.String:
+			e.indent = indent.Value
+		default:
+			return newError("TypeError: json_encode() expected argument #2 to be `int` or `str` got `%s`", args[1].Type())
+		}
+	}
+
+	if err := e.encode(args[0], 0); err != nil {
+		return err
+	}
+	return &object.String{Value: e.out.String()}
+}
+
+type jsonDecoder struct {
+	*json.Decoder
+	input []byte
+}
+
+// position returns the line and column of the byte at offset.
+func (d *jsonDecoder) position(offset int64) (int, int) {
+	if offset > int64(len(d.input)) {
+		offset = int64(len(d.input))
+	}
+	before := d.input[:offset]
+	line := bytes.Count(before, []byte{'\n'}) + 1
+	column := utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:]) + 1
+	return line, column
+}
+
+// skip returns the offset of the first byte at or after offset which is not
+// in cutset.
+func (d *jsonDecoder) skip(offset int64, cutset string) int64 {
+	rest := d.input[offset:]
+	return offset + int64(len(rest)-len(bytes.TrimLeft(rest, cutset)))
+}
+
+func (d *jsonDecoder) error(err error) *object.Error {
+	offset := d.InputOffset()
+	message := err.Error()
+
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &syntaxErr) && message != "unexpected end of JSON input":
+		// Offset points after the offending character.
+		offset = syntaxErr.Offset - 1
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		offset = int64(len(d.input))
+		message = "unexpected end of JSON input"
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	line, column := d.position(offset)
+	return newError("ValueError: json_decode() %s at line %d, column %d", message, line, column)
+}
+
+func (d *jsonDecoder) decode(depth int) (object.Object, *object.Error) {
+	offset := d.InputOffset()
+	token, err := d.Token()
+	if err != nil {
+		return nil, d.error(err)
+	}
+	if depth > maxJSONDepth {
+		line, column := d.position(d.InputOffset() - 1)
+		return nil, newError("ValueError: json_decode() exceeded maximum nesting depth at line %d, column %d", line, column)
+	}
+
+	switch token := token.(type) {
+	case nil:
+		return &object.Null{}, nil
+	case bool:
+		return &object.Boolean{Value: token}, nil
+	case string:
+		return &object.String{Value: token}, nil
+	case json.Number:
+		return d.number(token, offset)
+
+	case json.Delim:
+		if token == '[' {
+			var elements []object.Object
+			for d.More() {
+				element, err := d.decode(depth + 1)
+				if err != nil {
+					return nil, err
+				}
+				elements = append(elements, element)
+			}
+			if _, err := d.Token(); err != nil {
+				return nil, d.error(err)
+			}
+			return &object.Array{Elements: elements}, nil
+		}
+
+		hash := object.NewHash()
+		for d.More() {
+			key, err := d.Token()
+			if err != nil {
+				return nil, d.error(err)
+			}
+			value, e := d.decode(depth + 1)
+			if e != nil {
+				return nil, e
+			}
+			hash.Set(&object.String{Value: key.(string)}, value)
+		}
+		if _, err := d.Token(); err != nil {
+			return nil, d.error(err)
+		}
+		return hash, nil
+	}
+
+	return nil, newError("InternalError: json_decode() unexpected token %v", token)
+}
+
+// number converts a JSON number to an `int` unless it has a fraction or an
+// exponent.
+func (d *jsonDecoder) number(n json.Number, offset int64) (object.Object, *object.Error) {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		value, ok := new(big.Int).SetString(s, 10)
+		if ok {
+			return object.NewBigInteger(value), nil
+		}
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		line, column := d.position(d.skip(offset, " \t\r\n,:"))
+		return nil, newError("ValueError: json_decode() number %s out of range at line %d, column %d", s, line, column)
+	}
+	return &object.Float{Value: value}, nil
+}
+
+func JsonDecode(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"json_decode", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	input := []byte(args[0].(*object.String).Value)
+	d := &jsonDecoder{Decoder: json.NewDecoder(bytes.NewReader(input)), input: input}
+	d.UseNumber()
+
+	value, err := d.decode(0)
+	if err != nil {
+		return err
+	}
+
+	offset := d.InputOffset()
+	if _, e := d.Token(); e != io.EOF {
+		line, column := d.position(d.skip(offset, " \t\r\n"))
+		return newError("ValueError: json_decode() unexpected data after top-level value at line %d, column %d", line, column)
+	}
+	return value
+}
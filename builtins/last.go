@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Last(args ...object.Object) object.Object {
+func Last(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"last", args,
 		typing.ExactArgs(1),
@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Len(args ...object.Object) object.Object {
+func Len(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"len", args,
 		typing.ExactArgs(1),
@@ -13,6 +13,13 @@ func Len(args ...object.Object) object.Object {
 		return newError(err.Error())
 	}
 
+	if result, ok := callMethod(env, args[0], "__len__"); ok {
+		if result.Type() != object.IntegerType && result.Type() != object.ErrorType {
+			return newError("TypeError: len() __len__ returned `%s` instead of `int`", result.Type())
+		}
+		return result
+	}
+
 	if size, ok := args[0].(object.Sizeable); ok {
 		return &object.Integer{Value: int64(size.Len())}
 	}
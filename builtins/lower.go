@@ -7,7 +7,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Lower(args ...object.Object) object.Object {
+func Lower(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"lower", args,
 		typing.ExactArgs(1),
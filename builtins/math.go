@@ -0,0 +1,344 @@
+package builtins
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// maxFactorial limits the argument of math.factorial() so that a single
+// call cannot run for minutes.
+const maxFactorial = 1 << 16
+
+var MathModule = newHash(map[string]object.Object{
+	"pi":  &object.Float{Value: math.Pi},
+	"e":   &object.Float{Value: math.E},
+	"tau": &object.Float{Value: 2 * math.Pi},
+	"inf": &object.Float{Value: math.Inf(1)},
+	"nan": &object.Float{Value: math.NaN()},
+
+	"sqrt":  mathFunc("sqrt", math.Sqrt),
+	"exp":   mathFunc("exp", math.Exp),
+	"sin":   mathFunc("sin", math.Sin),
+	"cos":   mathFunc("cos", math.Cos),
+	"tan":   mathFunc("tan", math.Tan),
+	"asin":  mathFunc("asin", math.Asin),
+	"acos":  mathFunc("acos", math.Acos),
+	"atan":  mathFunc("atan", math.Atan),
+	"sinh":  mathFunc("sinh", math.Sinh),
+	"cosh":  mathFunc("cosh", math.Cosh),
+	"tanh":  mathFunc("tanh", math.Tanh),
+	"atan2": &object.Builtin{Name: "math.atan2", Fn: MathAtan2},
+	"hypot": &object.Builtin{Name: "math.hypot", Fn: MathHypot},
+
+	"degrees": mathFunc("degrees", func(x float64) float64 { return x * 180 / math.Pi }),
+	"radians": mathFunc("radians", func(x float64) float64 { return x * math.Pi / 180 }),
+
+	"log":   &object.Builtin{Name: "math.log", Fn: MathLog},
+	"log2":  logFunc("log2", math.Log2),
+	"log10": logFunc("log10", math.Log10),
+
+	"floor": roundFunc("floor", math.Floor),
+	"ceil":  roundFunc("ceil", math.Ceil),
+	"trunc": roundFunc("trunc", math.Trunc),
+	"round": &object.Builtin{Name: "math.round", Fn: MathRound},
+
+	"gcd":       &object.Builtin{Name: "math.gcd", Fn: MathGcd},
+	"lcm":       &object.Builtin{Name: "math.lcm", Fn: MathLcm},
+	"isqrt":     &object.Builtin{Name: "math.isqrt", Fn: MathIsqrt},
+	"factorial": &object.Builtin{Name: "math.factorial", Fn: MathFactorial},
+
+	"isnan": &object.Builtin{Name: "math.isnan", Fn: MathIsNaN},
+	"isinf": &object.Builtin{Name: "math.isinf", Fn: MathIsInf},
+})
+
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return obj.Float64()
+	case *object.Float:
+		return obj.Value
+	}
+	return math.NaN()
+}
+
+// toInteger converts an `int` or an integral `float` to *big.Int.
+func toInteger(name string, obj object.Object) (*big.Int, *object.Error) {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return obj.BigValue(), nil
+	case *object.Float:
+		if math.IsInf(obj.Value, 0) || math.IsNaN(obj.Value) || obj.Value != math.Trunc(obj.Value) {
+			return nil, newError("ValueError: %s() expected an integral value got %s", name, obj.Inspect())
+		}
+		value, _ := big.NewFloat(obj.Value).Int(nil)
+		return value, nil
+	}
+	return nil, newError("TypeError: %s() expected `int` or `float` got `%s`", name, obj.Type())
+}
+
+// mathFunc wraps a float function of one argument, reporting a domain error
+// when it turns a number into NaN.
+func mathFunc(name string, fn func(float64) float64) *object.Builtin {
+	name = "math." + name
+	return &object.Builtin{Name: name, Fn: func(env *object.Environment, args ...object.Object) object.Object {
+		if err := typing.Check(
+			name, args,
+			typing.ExactArgs(1),
+			typing.Numeric(),
+		); err != nil {
+			return newError(err.Error())
+		}
+
+		x := toFloat(args[0])
+		value := fn(x)
+		if math.IsNaN(value) && !math.IsNaN(x) {
+			return newError("ValueError: math domain error")
+		}
+		return &object.Float{Value: value}
+	}}
+}
+
+func logFunc(name string, fn func(float64) float64) *object.Builtin {
+	name = "math." + name
+	return &object.Builtin{Name: name, Fn: func(env *object.Environment, args ...object.Object) object.Object {
+		if err := typing.Check(
+			name, args,
+			typing.ExactArgs(1),
+			typing.Numeric(),
+		); err != nil {
+			return newError(err.Error())
+		}
+
+		return logOf(args[0], fn)
+	}}
+}
+
+// logOf computes fn(x) for positive x, including integers too large to be
+// represented as a float.
+func logOf(x object.Object, fn func(float64) float64) object.Object {
+	if integer, ok := x.(*object.Integer); ok && integer.IsBig() && integer.Sign() > 0 {
+		mant := new(big.Float).SetInt(integer.Big)
+		exp := mant.MantExp(mant)
+		m, _ := mant.Float64()
+		return &object.Float{Value: fn(m) + float64(exp)*fn(2)}
+	}
+
+	value := toFloat(x)
+	if value <= 0 {
+		return newError("ValueError: math domain error")
+	}
+	return &object.Float{Value: fn(value)}
+}
+
+// roundFunc wraps a float rounding function and returns the result as `int`.
+func roundFunc(name string, fn func(float64) float64) *object.Builtin {
+	name = "math." + name
+	return &object.Builtin{Name: name, Fn: func(env *object.Environment, args ...object.Object) object.Object {
+		if err := typing.Check(
+			name, args,
+			typing.ExactArgs(1),
+			typing.Numeric(),
+		); err != nil {
+			return newError(err.Error())
+		}
+
+		return floatToInteger(fn(toFloat(args[0])), args[0])
+	}}
+}
+
+func floatToInteger(value float64, orig object.Object) object.Object {
+	if integer, ok := orig.(*object.Integer); ok {
+		return integer
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return newError("ValueError: cannot convert float %s to int", (&object.Float{Value: value}).Inspect())
+	}
+	n, _ := big.NewFloat(value).Int(nil)
+	return object.NewBigInteger(n)
+}
+
+func MathLog(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.log", args,
+		typing.RangeOfArgs(1, 2),
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	value := logOf(args[0], math.Log)
+	if len(args) == 1 || value.Type() == object.ErrorType {
+		return value
+	}
+
+	base := logOf(args[1], math.Log)
+	if base.Type() == object.ErrorType {
+		return base
+	}
+	if base.(*object.Float).Value == 0 {
+		return newError("ZeroDivisionError: math.log() base must not be 1")
+	}
+	return &object.Float{Value: value.(*object.Float).Value / base.(*object.Float).Value}
+}
+
+func MathAtan2(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.atan2", args,
+		typing.ExactArgs(2),
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	return &object.Float{Value: math.Atan2(toFloat(args[0]), toFloat(args[1]))}
+}
+
+func MathHypot(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.hypot", args,
+		typing.ExactArgs(2),
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	return &object.Float{Value: math.Hypot(toFloat(args[0]), toFloat(args[1]))}
+}
+
+// MathRound rounds half to even. With ndigits it returns a `float` rounded
+// to that many decimal places, otherwise an `int`.
+func MathRound(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.round", args,
+		typing.RangeOfArgs(1, 2),
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	if len(args) == 1 {
+		return floatToInteger(math.RoundToEven(toFloat(args[0])), args[0])
+	}
+
+	ndigits, ok := args[1].(*object.Integer)
+	if !ok || ndigits.IsBig() {
+		return newError("TypeError: math.round() expected argument #2 to be `int` got `%s`", args[1].Type())
+	}
+
+	p := math.Pow(10, float64(ndigits.Value))
+	return &object.Float{Value: math.RoundToEven(toFloat(args[0])*p) / p}
+}
+
+func MathGcd(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.gcd", args,
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	result := new(big.Int)
+	for _, arg := range args {
+		n, err := toInteger("math.gcd", arg)
+		if err != nil {
+			return err
+		}
+		result.GCD(nil, nil, result, n.Abs(n))
+	}
+	return object.NewBigInteger(result)
+}
+
+func MathLcm(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.lcm", args,
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	result := big.NewInt(1)
+	for _, arg := range args {
+		n, err := toInteger("math.lcm", arg)
+		if err != nil {
+			return err
+		}
+		if n.Sign() == 0 {
+			return object.NewInteger(0)
+		}
+		n.Abs(n)
+		gcd := new(big.Int).GCD(nil, nil, result, n)
+		result.Mul(result, n.Quo(n, gcd))
+	}
+	return object.NewBigInteger(result)
+}
+
+func MathIsqrt(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.isqrt", args,
+		typing.ExactArgs(1),
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	n, err := toInteger("math.isqrt", args[0])
+	if err != nil {
+		return err
+	}
+	if n.Sign() < 0 {
+		return newError("ValueError: math.isqrt() argument must be nonnegative")
+	}
+	return object.NewBigInteger(n.Sqrt(n))
+}
+
+func MathFactorial(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.factorial", args,
+		typing.ExactArgs(1),
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	n, err := toInteger("math.factorial", args[0])
+	if err != nil {
+		return err
+	}
+	if n.Sign() < 0 {
+		return newError("ValueError: math.factorial() not defined for negative values")
+	}
+	if n.Cmp(big.NewInt(maxFactorial)) > 0 {
+		return newError("OverflowError: math.factorial() argument too large")
+	}
+	return object.NewBigInteger(new(big.Int).MulRange(1, n.Int64()))
+}
+
+func MathIsNaN(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.isnan", args,
+		typing.ExactArgs(1),
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	return &object.Boolean{Value: math.IsNaN(toFloat(args[0]))}
+}
+
+func MathIsInf(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"math.isinf", args,
+		typing.ExactArgs(1),
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	if args[0].Type() == object.IntegerType {
+		return &object.Boolean{Value: false}
+	}
+	return &object.Boolean{Value: math.IsInf(toFloat(args[0]), 0)}
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Max(args ...object.Object) object.Object {
+func Max(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"max", args,
 		typing.MinimumArgs(1),
@@ -0,0 +1,112 @@
+package builtins
+
+import (
+	. "github.com/Ars2014/ulang/object"
+)
+
+// iterableMethods are the methods of every builtin type which is iterable.
+var iterableMethods = []string{
+	"all", "any", "enumerate", "filter", "flat_map", "group_by", "map",
+	"reduce", "sorted", "sum", "zip",
+}
+
+// methodNames are the builtins which values of each builtin type expose as
+// methods, e.g. s.split(",") calls split(s, ",").
+var methodNames = map[Type][]string{
+	ArrayType: append([]string{
+		"find", "first", "join", "last", "len", "max", "min", "pop", "push",
+		"rest", "reversed",
+	}, iterableMethods...),
+	ChannelType: append([]string{"close", "len", "recv", "send"}, iterableMethods...),
+	FileType:    {"close", "readline", "write"},
+	FloatType:   {"abs", "int", "pow", "str"},
+	HashType: append([]string{
+		"delete", "get", "has", "items", "keys", "len", "merge", "pop",
+		"setdefault", "update", "values",
+	}, iterableMethods...),
+	IterType:    append([]string{"close", "list", "next"}, iterableMethods...),
+	IntegerType: {"abs", "bin", "chr", "divmod", "float", "hex", "oct", "pow", "str"},
+	MutexType:   {"lock", "unlock"},
+	RangeType:   append([]string{"len"}, iterableMethods...),
+	SetType: append([]string{
+		"add", "difference", "discard", "has", "intersection", "len",
+		"symmetric_difference", "union",
+	}, iterableMethods...),
+	StringType: append([]string{
+		"find", "float", "int", "len", "lower", "split", "upper",
+	}, iterableMethods...),
+	TaskType:  {"wait"},
+	TupleType: append([]string{"len"}, iterableMethods...),
+}
+
+// renamedMethods are methods of builtin types which are not the builtin of
+// the same name, e.g. r.match(s) calls re_match(r, s), t.format(layout)
+// calls time.format(t, layout) and s.remove(x) does not remove files.
+var renamedMethods = map[Type]map[string]*Builtin{
+	DurationType: {
+		"seconds": {Name: "time.seconds", Fn: TimeSeconds},
+	},
+	RegexType: {
+		"find_all": {Name: "re_find_all", Fn: ReFindAll},
+		"match":    {Name: "re_match", Fn: ReMatch},
+		"replace":  {Name: "re_replace", Fn: ReReplace},
+		"split":    {Name: "re_split", Fn: ReSplit},
+	},
+	SetType: {
+		"remove": {Name: "remove", Fn: SetRemove},
+	},
+	TimeType: {
+		"format": {Name: "time.format", Fn: TimeFormat},
+		"tz":     {Name: "time.tz", Fn: TimeTz},
+		"unix":   {Name: "time.unix", Fn: TimeUnix},
+	},
+}
+
+// attributes are the read-only fields of builtin types, e.g. r.pattern.
+var attributes = map[Type]map[string]func(obj Object) Object{
+	FileType: {
+		"mode": func(obj Object) Object { return &String{Value: obj.(*File).Mode} },
+		"name": func(obj Object) Object { return &String{Value: obj.(*File).Name} },
+	},
+	RegexType: {
+		"pattern": func(obj Object) Object { return &String{Value: obj.(*Regex).Regexp.String()} },
+	},
+}
+
+// Methods is the method table of each builtin type.
+var Methods = map[Type]map[string]*Builtin{}
+
+func init() {
+	for t, names := range methodNames {
+		Methods[t] = make(map[string]*Builtin, len(names))
+		for _, name := range names {
+			Methods[t][name] = Builtins[name]
+		}
+	}
+	for t, methods := range renamedMethods {
+		if Methods[t] == nil {
+			Methods[t] = make(map[string]*Builtin, len(methods))
+		}
+		for name, builtin := range methods {
+			Methods[t][name] = builtin
+		}
+	}
+}
+
+// Attribute returns the named attribute of obj.
+func Attribute(obj Object, name string) (Object, bool) {
+	attribute, ok := attributes[obj.Type()][name]
+	if !ok {
+		return nil, false
+	}
+	return attribute(obj), true
+}
+
+// Method returns the named method of obj bound to it.
+func Method(obj Object, name string) (*BuiltinMethod, bool) {
+	builtin, ok := Methods[obj.Type()][name]
+	if !ok {
+		return nil, false
+	}
+	return &BuiltinMethod{Self: obj, Builtin: builtin}, true
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Min(args ...object.Object) object.Object {
+func Min(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"min", args,
 		typing.MinimumArgs(1),
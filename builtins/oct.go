@@ -1,14 +1,11 @@
 package builtins
 
 import (
-	"fmt"
-	"strconv"
-
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Oct(args ...object.Object) object.Object {
+func Oct(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"oct", args,
 		typing.ExactArgs(1),
@@ -18,5 +15,5 @@ func Oct(args ...object.Object) object.Object {
 	}
 
 	i := args[0].(*object.Integer)
-	return &object.String{Value: fmt.Sprintf("0%s", strconv.FormatInt(i.Value, 8))}
+	return &object.String{Value: formatInteger(i, 8, "0")}
 }
@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Ord(args ...object.Object) object.Object {
+func Ord(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"ord", args,
 		typing.ExactArgs(1),
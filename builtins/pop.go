@@ -5,7 +5,38 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Pop(args ...object.Object) object.Object {
+func Pop(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"pop", args,
+		typing.RangeOfArgs(1, 3),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	// pop({"a": 1}, "a"), pop({"a": 1}, "b", 0)
+	if hash, ok := args[0].(*object.Hash); ok {
+		if err := typing.Check(
+			"pop", args,
+			typing.RangeOfArgs(2, 3),
+		); err != nil {
+			return newError(err.Error())
+		}
+		if err := checkKey("pop", args[1]); err != nil {
+			return err
+		}
+
+		value, ok := hash.Get(args[1])
+		if !ok {
+			if len(args) == 3 {
+				return args[2]
+			}
+			return newError("KeyError: pop() key %s not found", args[1].Inspect())
+		}
+		hash.Delete(args[1])
+		return value
+	}
+
+	// pop([1, 2, 3])
 	if err := typing.Check(
 		"pop", args,
 		typing.ExactArgs(1),
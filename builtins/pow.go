@@ -2,24 +2,45 @@ package builtins
 
 import (
 	"math"
+	"math/big"
 
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/typing"
 )
 
-func pow(x, y int64) int64 {
-	p := int64(1)
-	for y > 0 {
-		if y&1 != 0 {
-			p *= x
-		}
-		y >>= 1
-		x *= x
+// maxPowBits limits the size of integer powers so that a single call
+// cannot exhaust the host memory.
+const maxPowBits = 1 << 26
+
+func pow(x, y *object.Integer) object.Object {
+	if y.Sign() < 0 {
+		return floatPow(x, y)
+	}
+
+	base := x.BigValue()
+	if base.CmpAbs(big.NewInt(1)) > 0 && (y.IsBig() || y.Value > maxPowBits || y.Value > 0 && uint64(base.BitLen()) > maxPowBits/uint64(y.Value)) {
+		return newError("OverflowError: pow() result too large")
+	}
+
+	return object.NewBigInteger(base.Exp(base, y.BigValue(), nil))
+}
+
+// floatPow computes x ** y as a float. Like division by zero and the math
+// functions, it raises an error where there is no real result: for zero
+// to a negative power and a negative base to a fractional power.
+func floatPow(x, y object.Object) object.Object {
+	base, exp := toFloat(x), toFloat(y)
+	if base == 0 && exp < 0 {
+		return newError("division by zero: %s ** %s", x.Inspect(), y.Inspect())
+	}
+	value := math.Pow(base, exp)
+	if math.IsNaN(value) && !math.IsNaN(base) && !math.IsNaN(exp) {
+		return newError("ValueError: math domain error")
 	}
-	return p
+	return &object.Float{Value: value}
 }
 
-func Pow(args ...object.Object) object.Object {
+func Pow(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"pow", args,
 		typing.ExactArgs(2),
@@ -31,23 +52,10 @@ func Pow(args ...object.Object) object.Object {
 	case args[0].Type() == object.IntegerType && args[1].Type() == object.IntegerType:
 		x := args[0].(*object.Integer)
 		y := args[1].(*object.Integer)
-		value := pow(x.Value, y.Value)
-		return &object.Integer{Value: value}
-	case args[0].Type() == object.FloatType && args[1].Type() == object.FloatType:
-		x := args[0].(*object.Float)
-		y := args[1].(*object.Float)
-		value := math.Pow(x.Value, y.Value)
-		return &object.Float{Value: value}
-	case args[0].Type() == object.IntegerType && args[1].Type() == object.FloatType:
-		x := args[0].(*object.Integer)
-		y := args[1].(*object.Float)
-		value := math.Pow(float64(x.Value), y.Value)
-		return &object.Float{Value: value}
-	case args[0].Type() == object.FloatType && args[1].Type() == object.IntegerType:
-		x := args[0].(*object.Float)
-		y := args[1].(*object.Integer)
-		value := math.Pow(x.Value, float64(y.Value))
-		return &object.Float{Value: value}
+		return pow(x, y)
+	case args[0].Type() == object.FloatType && (args[1].Type() == object.FloatType || args[1].Type() == object.IntegerType),
+		args[0].Type() == object.IntegerType && args[1].Type() == object.FloatType:
+		return floatPow(args[0], args[1])
 
 	default:
 		return newError("pow() takes only 'float' or 'int' as parameters")
@@ -7,7 +7,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Print(args ...object.Object) object.Object {
+func Print(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"print", args,
 		typing.MinimumArgs(1),
@@ -15,7 +15,11 @@ func Print(args ...object.Object) object.Object {
 		return newError(err.Error())
 	}
 
-	_, _ = fmt.Fprintln(object.Stdout, args[0].String())
+	str, err := toString(env, "print", args[0])
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(object.Stdout, str)
 
 	return nil
 }
@@ -0,0 +1,286 @@
+package builtins
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// checkProcess refuses access to the process unless the host allows it.
+func checkProcess(name string) *object.Error {
+	if !object.AllowProcess {
+		return newError("PermissionError: %s() process access is disabled", name)
+	}
+	return nil
+}
+
+// GetEnv returns the value of an environment variable, or the default value
+// (null unless given) when it is not set.
+func GetEnv(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"getenv", args,
+		typing.RangeOfArgs(1, 2),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkProcess("getenv"); err != nil {
+		return err
+	}
+
+	if value, ok := os.LookupEnv(args[0].(*object.String).Value); ok {
+		return &object.String{Value: value}
+	}
+	if len(args) == 2 {
+		return args[1]
+	}
+	return nil
+}
+
+func SetEnv(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"setenv", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.StringType, object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkProcess("setenv"); err != nil {
+		return err
+	}
+
+	if err := os.Setenv(args[0].(*object.String).Value, args[1].(*object.String).Value); err != nil {
+		return newError("ValueError: setenv() %s", err)
+	}
+	return nil
+}
+
+func Environ(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"environ", args,
+		typing.ExactArgs(0),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkProcess("environ"); err != nil {
+		return err
+	}
+
+	vars := make(map[string]object.Object)
+	for _, kv := range os.Environ() {
+		if i := strings.IndexByte(kv, '='); i > 0 {
+			vars[kv[:i]] = &object.String{Value: kv[i+1:]}
+		}
+	}
+	return newHash(vars)
+}
+
+func Cwd(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"cwd", args,
+		typing.ExactArgs(0),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkProcess("cwd"); err != nil {
+		return err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return ioError(err)
+	}
+	return &object.String{Value: wd}
+}
+
+func Chdir(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"chdir", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkProcess("chdir"); err != nil {
+		return err
+	}
+
+	if err := os.Chdir(args[0].(*object.String).Value); err != nil {
+		return ioError(err)
+	}
+	return nil
+}
+
+// AtExit registers a function to be called when the script exits.
+func AtExit(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"at_exit", args,
+		typing.ExactArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkProcess("at_exit"); err != nil {
+		return err
+	}
+
+	if !object.IsCallable(args[0]) {
+		return newError("TypeError: at_exit() expected argument #1 to be `fn` got `%s`", args[0].Type())
+	}
+	env.AtExit(args[0])
+	return nil
+}
+
+// toTimeout converts a number of milliseconds or a duration to a positive
+// timeout.
+func toTimeout(name string, value object.Object) (time.Duration, *object.Error) {
+	var timeout time.Duration
+	if d, ok := value.(*object.Duration); ok {
+		timeout = d.Value
+	} else {
+		ms := toFloat(value) * float64(time.Millisecond)
+		if math.IsNaN(ms) || ms >= math.MaxInt64 {
+			return 0, newError("OverflowError: %s() timeout too large", name)
+		}
+		timeout = time.Duration(ms)
+	}
+
+	if timeout <= 0 {
+		return 0, newError("ValueError: %s() timeout must be positive", name)
+	}
+	return timeout, nil
+}
+
+type execOptions struct {
+	stdin   string
+	env     []string
+	cwd     string
+	timeout time.Duration
+}
+
+func parseExecOptions(hash *object.Hash) (*execOptions, *object.Error) {
+	opts := &execOptions{}
+	for _, pair := range hash.Pairs() {
+		key, ok := pair.Key.(*object.String)
+		if !ok {
+			return nil, newError("TypeError: exec() option names must be `str` got `%s`", pair.Key.Type())
+		}
+
+		switch value := pair.Value.(type) {
+		case *object.String:
+			switch key.Value {
+			case "stdin":
+				opts.stdin = value.Value
+				continue
+			case "cwd":
+				opts.cwd = value.Value
+				continue
+			}
+		case *object.Hash:
+			if key.Value == "env" {
+				opts.env = os.Environ()
+				var names []string
+				vars := make(map[string]string)
+				for _, pair := range value.Pairs() {
+					name, ok := pair.Key.(*object.String)
+					if !ok {
+						return nil, newError("TypeError: exec() environment variable names must be `str` got `%s`", pair.Key.Type())
+					}
+					names = append(names, name.Value)
+					vars[name.Value] = pair.Value.String()
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					opts.env = append(opts.env, name+"="+vars[name])
+				}
+				continue
+			}
+		case *object.Integer, *object.Float, *object.Duration:
+			if key.Value == "timeout" {
+				var err *object.Error
+				if opts.timeout, err = toTimeout("exec", value); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		switch key.Value {
+		case "stdin", "cwd", "env", "timeout":
+			return nil, newError("TypeError: exec() invalid type `%s` for option %q", pair.Value.Type(), key.Value)
+		default:
+			return nil, newError("ValueError: exec() unknown option %q", key.Value)
+		}
+	}
+	return opts, nil
+}
+
+// Exec runs a command and returns its output and exit code. Variables in
+// the env option are added to the environment of the script.
+func Exec(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"exec", args,
+		typing.RangeOfArgs(1, 3),
+		typing.WithTypes(object.StringType, object.ArrayType, object.HashType),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkProcess("exec"); err != nil {
+		return err
+	}
+
+	var argv []string
+	if len(args) > 1 {
+		for _, arg := range args[1].(*object.Array).Elements {
+			argv = append(argv, arg.String())
+		}
+	}
+
+	opts := &execOptions{}
+	if len(args) > 2 {
+		var err *object.Error
+		if opts, err = parseExecOptions(args[2].(*object.Hash)); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0].(*object.String).Value, argv...)
+	cmd.Stdin = strings.NewReader(opts.stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = opts.env
+	cmd.Dir = opts.cwd
+
+	var err error
+	env.Block(func() { err = cmd.Run() })
+	if ctx.Err() == context.DeadlineExceeded {
+		return newError("TimeoutError: exec() command timed out after %s", opts.timeout)
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return ioError(err)
+	}
+
+	return newHash(map[string]object.Object{
+		"stdout": &object.String{Value: stdout.String()},
+		"stderr": &object.String{Value: stderr.String()},
+		"code":   object.NewInteger(int64(cmd.ProcessState.ExitCode())),
+	})
+}
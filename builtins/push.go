@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Push(args ...object.Object) object.Object {
+func Push(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"push", args,
 		typing.ExactArgs(2),
@@ -0,0 +1,137 @@
+package builtins
+
+import (
+	"math/big"
+	"math/rand"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// Seed makes the random builtins of the interpreter reproducible.
+func Seed(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"seed", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.IntegerType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	seed := args[0].(*object.Integer)
+	if seed.IsBig() {
+		return newError("OverflowError: seed() argument too large")
+	}
+
+	env.Seed(seed.Value)
+	return nil
+}
+
+// Random returns a float in [0, 1).
+func Random(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"random", args,
+		typing.ExactArgs(0),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	var value float64
+	env.Random(func(r *rand.Rand) {
+		value = r.Float64()
+	})
+	return &object.Float{Value: value}
+}
+
+// RandInt returns an integer in [a, b], both ends included.
+func RandInt(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"randint", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.IntegerType, object.IntegerType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	a, b := args[0].(*object.Integer), args[1].(*object.Integer)
+	if a.Compare(b) > 0 {
+		return newError("ValueError: randint() empty range [%s, %s]", a.Inspect(), b.Inspect())
+	}
+
+	n := b.BigValue()
+	n.Sub(n, a.BigValue()).Add(n, big.NewInt(1))
+
+	value := new(big.Int)
+	env.Random(func(r *rand.Rand) {
+		value.Rand(r, n)
+	})
+	return object.NewBigInteger(value.Add(value, a.BigValue()))
+}
+
+func Choice(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"choice", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.ArrayType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	elements := args[0].(*object.Array).Elements
+	if len(elements) == 0 {
+		return newError("IndexError: choice() from an empty array")
+	}
+
+	var i int
+	env.Random(func(r *rand.Rand) {
+		i = r.Intn(len(elements))
+	})
+	return elements[i]
+}
+
+// Shuffle shuffles the array in place and returns it.
+func Shuffle(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"shuffle", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.ArrayType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	arr := args[0].(*object.Array)
+	env.Random(func(r *rand.Rand) {
+		r.Shuffle(len(arr.Elements), func(i, j int) {
+			arr.Elements[i], arr.Elements[j] = arr.Elements[j], arr.Elements[i]
+		})
+	})
+	return arr
+}
+
+// Sample returns k distinct elements of the array in random order.
+func Sample(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"sample", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.ArrayType, object.IntegerType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	elements := args[0].(*object.Array).Elements
+	k := args[1].(*object.Integer)
+	if k.Sign() < 0 || k.Compare(object.NewInteger(int64(len(elements)))) > 0 {
+		return newError("ValueError: sample() larger than population or is negative")
+	}
+
+	pool := make([]object.Object, len(elements))
+	copy(pool, elements)
+	env.Random(func(r *rand.Rand) {
+		// A partial Fisher-Yates shuffle picks the first k elements.
+		for i := 0; i < int(k.Value); i++ {
+			j := i + r.Intn(len(pool)-i)
+			pool[i], pool[j] = pool[j], pool[i]
+		}
+	})
+	return &object.Array{Elements: pool[:k.Value]}
+}
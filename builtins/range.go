@@ -0,0 +1,40 @@
+package builtins
+
+import (
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// ToRange returns the lazy range of integers from start up to, but excluding,
+// stop by step as in range(stop) or range(start, stop[, step]).
+func ToRange(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"range", args,
+		typing.RangeOfArgs(1, 3),
+		typing.WithTypes(object.IntegerType, object.IntegerType, object.IntegerType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	bounds := make([]int64, len(args))
+	for i, arg := range args {
+		if arg.(*object.Integer).IsBig() {
+			return newError("OverflowError: range() argument #%d is too large", i+1)
+		}
+		bounds[i] = arg.(*object.Integer).Value
+	}
+
+	r := &object.Range{Step: 1}
+	switch len(bounds) {
+	case 1:
+		r.Stop = bounds[0]
+	case 2:
+		r.Start, r.Stop = bounds[0], bounds[1]
+	case 3:
+		r.Start, r.Stop, r.Step = bounds[0], bounds[1], bounds[2]
+	}
+	if r.Step == 0 {
+		return newError("ValueError: range() step must not be zero")
+	}
+	return r
+}
@@ -0,0 +1,209 @@
+package builtins
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// maxCachedPatterns bounds the cache of patterns passed as strings.
+const maxCachedPatterns = 256
+
+var patternCache = struct {
+	sync.Mutex
+	patterns map[string]*regexp.Regexp
+}{patterns: make(map[string]*regexp.Regexp)}
+
+func compilePattern(name, pattern string) (*regexp.Regexp, *object.Error) {
+	patternCache.Lock()
+	defer patternCache.Unlock()
+
+	if re, ok := patternCache.patterns[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, newError("ValueError: %s() invalid pattern: %s", name, err)
+	}
+
+	if len(patternCache.patterns) >= maxCachedPatterns {
+		patternCache.patterns = make(map[string]*regexp.Regexp)
+	}
+	patternCache.patterns[pattern] = re
+	return re, nil
+}
+
+// regexArgs checks that the arguments start with a pattern, given either as
+// `str` or as `regex`, followed by the subject string.
+func regexArgs(name string, args []object.Object, checks ...typing.CheckFunc) (*regexp.Regexp, string, *object.Error) {
+	checks = append([]typing.CheckFunc{typing.MinimumArgs(2)}, checks...)
+	if err := typing.Check(name, args, checks...); err != nil {
+		return nil, "", newError(err.Error())
+	}
+
+	s, ok := args[1].(*object.String)
+	if !ok {
+		return nil, "", newError("TypeError: %s() expected argument #2 to be `str` got `%s`", name, args[1].Type())
+	}
+
+	switch pattern := args[0].(type) {
+	case *object.Regex:
+		return pattern.Regexp, s.Value, nil
+	case *object.String:
+		re, err := compilePattern(name, pattern.Value)
+		return re, s.Value, err
+	default:
+		return nil, "", newError("TypeError: %s() expected argument #1 to be `str` or `regex` got `%s`", name, args[0].Type())
+	}
+}
+
+// runeIndex converts byte offsets of s into rune offsets. Offsets must be
+// passed in increasing order.
+type runeIndex struct {
+	s          string
+	byteOffset int
+	runeOffset int
+}
+
+func (ri *runeIndex) at(offset int) int {
+	ri.runeOffset += utf8.RuneCountInString(ri.s[ri.byteOffset:offset])
+	ri.byteOffset = offset
+	return ri.runeOffset
+}
+
+// matchObject describes a match as a hash with the matched text, its rune
+// offsets, the positional groups and the named groups. Groups which did not
+// participate in the match are null.
+func matchObject(re *regexp.Regexp, s string, loc []int, ri *runeIndex) *object.Hash {
+	group := func(i int) object.Object {
+		if loc[2*i] < 0 {
+			return &object.Null{}
+		}
+		return &object.String{Value: s[loc[2*i]:loc[2*i+1]]}
+	}
+
+	groups := make([]object.Object, re.NumSubexp())
+	named := object.NewHash() // in the order of the pattern
+	for i, name := range re.SubexpNames()[1:] {
+		groups[i] = group(i + 1)
+		if name != "" {
+			named.Set(&object.String{Value: name}, groups[i])
+		}
+	}
+
+	return newHash(map[string]object.Object{
+		"text":   group(0),
+		"start":  object.NewInteger(int64(ri.at(loc[0]))),
+		"end":    object.NewInteger(int64(ri.at(loc[1]))),
+		"groups": &object.Array{Elements: groups},
+		"named":  named,
+	})
+}
+
+func ReCompile(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"re_compile", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	re, err := regexp.Compile(args[0].(*object.String).Value)
+	if err != nil {
+		return newError("ValueError: re_compile() invalid pattern: %s", err)
+	}
+	return &object.Regex{Regexp: re}
+}
+
+// ReMatch returns the leftmost match of the pattern in the string or null.
+func ReMatch(env *object.Environment, args ...object.Object) object.Object {
+	re, s, err := regexArgs("re_match", args, typing.ExactArgs(2))
+	if err != nil {
+		return err
+	}
+
+	loc := re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return nil
+	}
+	return matchObject(re, s, loc, &runeIndex{s: s})
+}
+
+func ReFindAll(env *object.Environment, args ...object.Object) object.Object {
+	re, s, err := regexArgs("re_find_all", args, typing.ExactArgs(2))
+	if err != nil {
+		return err
+	}
+
+	ri := &runeIndex{s: s}
+	var matches []object.Object
+	for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+		matches = append(matches, matchObject(re, s, loc, ri))
+	}
+	return &object.Array{Elements: matches}
+}
+
+// ReReplace replaces all matches with a template, in which $1 or ${name}
+// refer to groups, or with the result of calling a function with the match.
+func ReReplace(env *object.Environment, args ...object.Object) object.Object {
+	re, s, err := regexArgs("re_replace", args, typing.ExactArgs(3))
+	if err != nil {
+		return err
+	}
+
+	if repl, ok := args[2].(*object.String); ok {
+		return &object.String{Value: re.ReplaceAllString(s, repl.Value)}
+	}
+	if !object.IsCallable(args[2]) {
+		return newError("TypeError: re_replace() expected argument #3 to be `str` or `fn` got `%s`", args[2].Type())
+	}
+
+	var out strings.Builder
+	ri := &runeIndex{s: s}
+	last := 0
+	for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+		result := object.Apply(env, args[2], []object.Object{matchObject(re, s, loc, ri)})
+		if result.Type() == object.ErrorType {
+			return result
+		}
+		str, ok := result.(*object.String)
+		if !ok {
+			return newError("TypeError: re_replace() expected replacement function to return `str` got `%s`", result.Type())
+		}
+
+		out.WriteString(s[last:loc[0]])
+		out.WriteString(str.Value)
+		last = loc[1]
+	}
+	out.WriteString(s[last:])
+	return &object.String{Value: out.String()}
+}
+
+func ReSplit(env *object.Environment, args ...object.Object) object.Object {
+	re, s, err := regexArgs("re_split", args, typing.RangeOfArgs(2, 3))
+	if err != nil {
+		return err
+	}
+
+	n := -1
+	if len(args) == 3 {
+		limit, ok := args[2].(*object.Integer)
+		if !ok || limit.IsBig() {
+			return newError("TypeError: re_split() expected argument #3 to be `int` got `%s`", args[2].Type())
+		}
+		n = int(limit.Value)
+	}
+
+	parts := re.Split(s, n)
+	elements := make([]object.Object, len(parts))
+	for i, part := range parts {
+		elements[i] = &object.String{Value: part}
+	}
+	return &object.Array{Elements: elements}
+}
@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Rest(args ...object.Object) object.Object {
+func Rest(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"rest", args,
 		typing.ExactArgs(1),
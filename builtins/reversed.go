@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Reversed(args ...object.Object) object.Object {
+func Reversed(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"reversed", args,
 		typing.ExactArgs(1),
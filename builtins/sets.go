@@ -0,0 +1,125 @@
+package builtins
+
+import (
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// checkElement reports an error unless obj can be stored in a set.
+func checkElement(obj object.Object) *object.Error {
+	if !object.IsHashable(obj) {
+		return newError("unusable as set element: %s", obj.Type())
+	}
+	return nil
+}
+
+// ToSet returns a set of the elements of an iterable, or an empty set
+// without arguments.
+func ToSet(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"set", args,
+		typing.RangeOfArgs(0, 1),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	set := object.NewSet()
+	if len(args) == 0 {
+		return set
+	}
+
+	iterable, err := iterableOf(env, "set", args[0])
+	if err != nil {
+		return err
+	}
+
+	iter := iterable.Iter()
+	for {
+		el, ok := iter.Next()
+		if !ok {
+			return set
+		}
+		if el.Type() == object.ErrorType {
+			return el
+		}
+		if err := checkElement(el); err != nil {
+			object.CloseIterator(iter)
+			return err
+		}
+		set.Add(el)
+	}
+}
+
+// Add adds an element to a set and reports whether it was not already
+// present.
+func Add(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"add", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.SetType),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkElement(args[1]); err != nil {
+		return err
+	}
+
+	return &object.Boolean{Value: args[0].(*object.Set).Add(args[1])}
+}
+
+// removeElement returns a builtin which removes an element from a set and
+// reports whether it was present.
+func removeElement(name string) object.BuiltinFunction {
+	return func(env *object.Environment, args ...object.Object) object.Object {
+		if err := typing.Check(
+			name, args,
+			typing.ExactArgs(2),
+			typing.WithTypes(object.SetType),
+		); err != nil {
+			return newError(err.Error())
+		}
+		if err := checkElement(args[1]); err != nil {
+			return err
+		}
+
+		return &object.Boolean{Value: args[0].(*object.Set).Remove(args[1])}
+	}
+}
+
+// setOperation returns a builtin applying op to two or more sets from left
+// to right.
+func setOperation(name string, op func(a, b *object.Set) *object.Set) object.BuiltinFunction {
+	return func(env *object.Environment, args ...object.Object) object.Object {
+		if err := typing.Check(
+			name, args,
+			typing.MinimumArgs(2),
+		); err != nil {
+			return newError(err.Error())
+		}
+		for i, arg := range args {
+			if _, ok := arg.(*object.Set); !ok {
+				return newError("TypeError: %s() expected argument #%d to be `set` got `%s`", name, i+1, arg.Type())
+			}
+		}
+
+		set := args[0].(*object.Set)
+		for _, arg := range args[1:] {
+			set = op(set, arg.(*object.Set))
+		}
+		return set
+	}
+}
+
+var (
+	Discard = removeElement("discard")
+	// SetRemove is the remove method of sets, which is kept apart from
+	// the remove builtin that removes files.
+	SetRemove = removeElement("remove")
+)
+
+var (
+	Union               = setOperation("union", (*object.Set).Union)
+	Intersection        = setOperation("intersection", (*object.Set).Intersection)
+	Difference          = setOperation("difference", (*object.Set).Difference)
+	SymmetricDifference = setOperation("symmetric_difference", (*object.Set).SymmetricDifference)
+)
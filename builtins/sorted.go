@@ -7,17 +7,67 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Sorted(args ...object.Object) object.Object {
+// Sorted returns an array of the elements of an iterable in ascending order,
+// as in sorted(iterable[, key[, reverse]]). Elements are compared by the
+// result of calling key with them unless it is null. The sort is stable,
+// also when reversed.
+func Sorted(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
-		"sort", args,
-		typing.ExactArgs(1),
-		typing.WithTypes(object.ArrayType),
+		"sorted", args,
+		typing.RangeOfArgs(1, 3),
 	); err != nil {
 		return newError(err.Error())
 	}
 
-	arr := args[0].(*object.Array)
-	newArray := arr.Copy()
-	sort.Sort(newArray)
-	return newArray
+	var key object.Object
+	if len(args) > 1 && args[1].Type() != object.NullType {
+		if err := checkCallable("sorted", 2, args[1]); err != nil {
+			return err
+		}
+		key = args[1]
+	}
+	reverse := len(args) > 2 && args[2].Bool()
+
+	elements := []object.Object{}
+	keys := []object.Object{}
+	err := each(env, "sorted", args[0], func(el object.Object) (bool, object.Object) {
+		k := el
+		if key != nil {
+			k = object.Apply(env, key, []object.Object{el})
+		}
+		elements = append(elements, el)
+		keys = append(keys, k)
+		return true, k
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Stable(&sortByKey{elements: elements, keys: keys, reverse: reverse})
+	return &object.Array{Elements: elements}
+}
+
+// sortByKey sorts elements by their keys, which are kept in the same order.
+type sortByKey struct {
+	elements, keys []object.Object
+	reverse        bool
+}
+
+func (s *sortByKey) Len() int {
+	return len(s.elements)
+}
+
+func (s *sortByKey) Swap(i, j int) {
+	s.elements[i], s.elements[j] = s.elements[j], s.elements[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+func (s *sortByKey) Less(i, j int) bool {
+	if s.reverse {
+		i, j = j, i
+	}
+	if cmp, ok := s.keys[i].(object.Comparable); ok {
+		return cmp.Compare(s.keys[j]) == -1
+	}
+	return false
 }
@@ -7,7 +7,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Split(args ...object.Object) object.Object {
+func Split(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"split", args,
 		typing.RangeOfArgs(1, 2),
@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Str(args ...object.Object) object.Object {
+func Str(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"str", args,
 		typing.ExactArgs(1),
@@ -13,5 +13,9 @@ func Str(args ...object.Object) object.Object {
 		return newError(err.Error())
 	}
 
-	return &object.String{Value: args[0].String()}
+	str, err := toString(env, "str", args[0])
+	if err != nil {
+		return err
+	}
+	return &object.String{Value: str}
 }
@@ -0,0 +1,257 @@
+package builtins
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// Spawn calls a function with the given arguments as a new task and returns
+// a handle to wait for its result. Tasks take turns running: one runs until
+// it blocks, e.g. on a channel, a mutex, wait, time.sleep or I/O.
+func Spawn(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"spawn", args,
+		typing.MinimumArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+	if err := checkCallable("spawn", 1, args[0]); err != nil {
+		return err
+	}
+
+	fn := args[0]
+	fnArgs := append([]object.Object{}, args[1:]...)
+	task := object.NewTask()
+	env.Spawn(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				task.Finish(newError("InternalError: %v", r))
+			}
+		}()
+		task.Finish(object.Apply(env, fn, fnArgs))
+	})
+	return task
+}
+
+// Wait waits for tasks to finish and returns their results. Given a single
+// task it returns its result, otherwise an array of the results of the
+// tasks, which may also be passed as an array. An error raised by a task is
+// raised again.
+func Wait(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"wait", args,
+		typing.MinimumArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	single := len(args) == 1
+	if arr, ok := args[0].(*object.Array); ok && single {
+		args = arr.Elements
+		single = false
+	}
+
+	tasks := make([]*object.Task, len(args))
+	for i, arg := range args {
+		task, ok := arg.(*object.Task)
+		if !ok {
+			return newError("TypeError: wait() expected a `task` got `%s`", arg.Type())
+		}
+		tasks[i] = task
+	}
+
+	results := make([]object.Object, len(tasks))
+	for i, task := range tasks {
+		env.Block(func() { <-task.Done() })
+		results[i] = task.Result()
+		if results[i].Type() == object.ErrorType {
+			return results[i]
+		}
+	}
+	if single {
+		return results[0]
+	}
+	return &object.Array{Elements: results}
+}
+
+// ToChannel returns a channel which buffers up to the given number of
+// objects, or an unbuffered channel without arguments.
+func ToChannel(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"channel", args,
+		typing.RangeOfArgs(0, 1),
+		typing.WithTypes(object.IntegerType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	size := 0
+	if len(args) == 1 {
+		n := args[0].(*object.Integer)
+		if n.IsBig() || n.Value < 0 || n.Value > maxChannelSize {
+			return newError("ValueError: channel() size must be between 0 and %d", maxChannelSize)
+		}
+		size = int(n.Value)
+	}
+	return object.NewChannel(env, size)
+}
+
+const maxChannelSize = 1 << 20
+
+// Send sends an object on a channel, blocking until there is room for it.
+func Send(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"send", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.ChannelType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	if !args[0].(*object.Channel).Send(args[1]) {
+		return newError("ValueError: send() on closed channel")
+	}
+	return nil
+}
+
+// Recv receives an object from a channel, blocking until there is one. It
+// returns null once the channel is closed and has no objects left.
+func Recv(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"recv", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.ChannelType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	obj, ok := args[0].(*object.Channel).Recv()
+	if !ok {
+		return nil
+	}
+	return obj
+}
+
+// Select waits until one of several channel operations can proceed and
+// performs it, as in select([ch, (out, value)]). A channel receives from it
+// and a (channel, value) pair sends to it. It returns a tuple of the index
+// of the operation and the value received, which is null for sends and
+// closed channels. Unless block is true, it returns (-1, null) at once when
+// no operation can proceed.
+func Select(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"select", args,
+		typing.RangeOfArgs(1, 2),
+		typing.WithTypes(object.ArrayType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	ops := args[0].(*object.Array).Elements
+	cases := make([]reflect.SelectCase, 0, len(ops)+1)
+	for _, op := range ops {
+		switch op := op.(type) {
+		case *object.Channel:
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(op.Chan())})
+		case *object.Tuple:
+			if len(op.Elements) != 2 || op.Elements[0].Type() != object.ChannelType {
+				return newError("TypeError: select() expected a send to be a (channel, value) tuple")
+			}
+			ch := op.Elements[0].(*object.Channel)
+			if ch.Closed() {
+				return newError("ValueError: select() send on closed channel")
+			}
+			cases = append(cases, reflect.SelectCase{
+				Dir:  reflect.SelectSend,
+				Chan: reflect.ValueOf(ch.Chan()),
+				Send: reflect.ValueOf(&op.Elements[1]).Elem(),
+			})
+		default:
+			return newError("TypeError: select() expected a `channel` or `tuple` got `%s`", op.Type())
+		}
+	}
+
+	block := len(args) < 2 || args[1].Bool()
+	if block && len(cases) == 0 {
+		return newError("ValueError: select() would block forever without operations")
+	}
+	if !block {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+	}
+
+	var (
+		chosen int
+		value  reflect.Value
+		ok     bool
+		err    *object.Error
+	)
+	env.Block(func() {
+		defer func() {
+			// A channel was closed while sending to it was blocked.
+			if recover() != nil {
+				err = newError("ValueError: select() send on closed channel")
+			}
+		}()
+		if !block {
+			// Let other tasks run, as polling in a loop would starve them.
+			runtime.Gosched()
+		}
+		chosen, value, ok = reflect.Select(cases)
+	})
+	if err != nil {
+		return err
+	}
+
+	if chosen == len(ops) {
+		chosen = -1
+	}
+	var received object.Object = &object.Null{}
+	if ok {
+		received = value.Interface().(object.Object)
+	}
+	return &object.Tuple{Elements: []object.Object{&object.Integer{Value: int64(chosen)}, received}}
+}
+
+// ToMutex returns an unlocked mutex.
+func ToMutex(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"mutex", args,
+		typing.ExactArgs(0),
+	); err != nil {
+		return newError(err.Error())
+	}
+	return object.NewMutex(env)
+}
+
+// Lock locks a mutex, blocking until it is unlocked by the task holding it.
+func Lock(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"lock", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.MutexType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	args[0].(*object.Mutex).Lock()
+	return nil
+}
+
+// Unlock unlocks a locked mutex.
+func Unlock(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"unlock", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.MutexType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	if !args[0].(*object.Mutex).Unlock() {
+		return newError("ValueError: unlock() of unlocked mutex")
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package builtins
+
+import (
+	"math"
+	"time"
+	// Embed the timezone database so that time.tz() works on hosts without
+	// one installed.
+	_ "time/tzdata"
+
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+var TimeModule = newHash(map[string]object.Object{
+	"nanosecond":  &object.Duration{Value: time.Nanosecond},
+	"microsecond": &object.Duration{Value: time.Microsecond},
+	"millisecond": &object.Duration{Value: time.Millisecond},
+	"second":      &object.Duration{Value: time.Second},
+	"minute":      &object.Duration{Value: time.Minute},
+	"hour":        &object.Duration{Value: time.Hour},
+
+	"rfc3339":      &object.String{Value: time.RFC3339},
+	"rfc3339_nano": &object.String{Value: time.RFC3339Nano},
+	"rfc1123":      &object.String{Value: time.RFC1123},
+	"date_time":    &object.String{Value: "2006-01-02 15:04:05"},
+	"date_only":    &object.String{Value: "2006-01-02"},
+	"time_only":    &object.String{Value: "15:04:05"},
+
+	"now":       &object.Builtin{Name: "time.now", Fn: TimeNow},
+	"clock":     &object.Builtin{Name: "time.clock", Fn: TimeClock},
+	"sleep":     &object.Builtin{Name: "time.sleep", Fn: TimeSleep},
+	"parse":     &object.Builtin{Name: "time.parse", Fn: TimeParse},
+	"format":    &object.Builtin{Name: "time.format", Fn: TimeFormat},
+	"tz":        &object.Builtin{Name: "time.tz", Fn: TimeTz},
+	"unix":      &object.Builtin{Name: "time.unix", Fn: TimeUnix},
+	"from_unix": &object.Builtin{Name: "time.from_unix", Fn: TimeFromUnix},
+	"duration":  &object.Builtin{Name: "time.duration", Fn: TimeDuration},
+	"seconds":   &object.Builtin{Name: "time.seconds", Fn: TimeSeconds},
+})
+
+func loadLocation(name, tz string) (*time.Location, *object.Error) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, newError("ValueError: %s() unknown time zone %q", name, tz)
+	}
+	return loc, nil
+}
+
+func TimeNow(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.now", args,
+		typing.ExactArgs(0),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	return &object.Time{Value: object.Clock.Now()}
+}
+
+// TimeClock returns the seconds elapsed on a monotonic clock, which is only
+// meaningful to measure intervals.
+func TimeClock(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.clock", args,
+		typing.ExactArgs(0),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	return &object.Float{Value: object.Clock.Monotonic().Seconds()}
+}
+
+// TimeSleep pauses for the given number of milliseconds or duration.
+func TimeSleep(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.sleep", args,
+		typing.ExactArgs(1),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	var d time.Duration
+	switch arg := args[0].(type) {
+	case *object.Duration:
+		d = arg.Value
+	case *object.Integer, *object.Float:
+		ms := toFloat(arg) * float64(time.Millisecond)
+		if math.IsNaN(ms) || ms >= math.MaxInt64 {
+			return newError("OverflowError: time.sleep() argument too large")
+		}
+		d = time.Duration(ms)
+	default:
+		return newError("TypeError: time.sleep() expected argument #1 to be `int`, `float` or `duration` got `%s`", args[0].Type())
+	}
+
+	if d < 0 {
+		return newError("ValueError: time.sleep() length must be non-negative")
+	}
+	env.Block(func() { object.Clock.Sleep(d) })
+	return nil
+}
+
+// TimeParse parses a time using a Go reference layout. Times without a zone
+// are taken to be UTC unless a time zone name is given.
+func TimeParse(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.parse", args,
+		typing.RangeOfArgs(2, 3),
+		typing.WithTypes(object.StringType, object.StringType, object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	loc := time.UTC
+	if len(args) == 3 {
+		var err *object.Error
+		if loc, err = loadLocation("time.parse", args[2].(*object.String).Value); err != nil {
+			return err
+		}
+	}
+
+	t, err := time.ParseInLocation(args[1].(*object.String).Value, args[0].(*object.String).Value, loc)
+	if err != nil {
+		return newError("ValueError: time.parse() %s", err)
+	}
+	return &object.Time{Value: t}
+}
+
+func TimeFormat(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.format", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.TimeType, object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	t := args[0].(*object.Time).Value
+	return &object.String{Value: t.Format(args[1].(*object.String).Value)}
+}
+
+// TimeTz returns the same instant displayed in the named time zone.
+func TimeTz(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.tz", args,
+		typing.ExactArgs(2),
+		typing.WithTypes(object.TimeType, object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	loc, err := loadLocation("time.tz", args[1].(*object.String).Value)
+	if err != nil {
+		return err
+	}
+	return &object.Time{Value: args[0].(*object.Time).Value.In(loc)}
+}
+
+func TimeUnix(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.unix", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.TimeType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	return object.NewInteger(args[0].(*object.Time).Value.Unix())
+}
+
+// TimeFromUnix returns the UTC time for seconds since the Unix epoch.
+func TimeFromUnix(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.from_unix", args,
+		typing.ExactArgs(1),
+		typing.Numeric(),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	if seconds, ok := args[0].(*object.Integer); ok {
+		if seconds.IsBig() {
+			return newError("OverflowError: time.from_unix() argument too large")
+		}
+		return &object.Time{Value: time.Unix(seconds.Value, 0).UTC()}
+	}
+
+	value := args[0].(*object.Float).Value
+	if math.IsNaN(value) || math.IsInf(value, 0) || math.Abs(value) >= 1<<62 {
+		return newError("OverflowError: time.from_unix() argument too large")
+	}
+	sec, frac := math.Modf(value)
+	return &object.Time{Value: time.Unix(int64(sec), int64(frac*1e9)).UTC()}
+}
+
+// TimeDuration parses a duration such as "1h30m" or "250ms".
+func TimeDuration(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.duration", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.StringType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	d, err := time.ParseDuration(args[0].(*object.String).Value)
+	if err != nil {
+		return newError("ValueError: time.duration() %s", err)
+	}
+	return &object.Duration{Value: d}
+}
+
+func TimeSeconds(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"time.seconds", args,
+		typing.ExactArgs(1),
+		typing.WithTypes(object.DurationType),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	return &object.Float{Value: args[0].(*object.Duration).Value.Seconds()}
+}
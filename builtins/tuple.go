@@ -0,0 +1,32 @@
+package builtins
+
+import (
+	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/typing"
+)
+
+// ToTuple returns a tuple of the elements of an array, or an empty tuple
+// without arguments.
+func ToTuple(env *object.Environment, args ...object.Object) object.Object {
+	if err := typing.Check(
+		"tuple", args,
+		typing.RangeOfArgs(0, 1),
+	); err != nil {
+		return newError(err.Error())
+	}
+
+	if len(args) == 0 {
+		return &object.Tuple{Elements: []object.Object{}}
+	}
+
+	switch arg := args[0].(type) {
+	case *object.Tuple:
+		return arg
+	case *object.Array:
+		elements := make([]object.Object, len(arg.Elements))
+		copy(elements, arg.Elements)
+		return &object.Tuple{Elements: elements}
+	default:
+		return newError("TypeError: tuple() expected argument #1 to be `array` or `tuple` got `%s`", arg.Type())
+	}
+}
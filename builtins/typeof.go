@@ -5,7 +5,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func TypeOf(args ...object.Object) object.Object {
+func TypeOf(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"type", args,
 		typing.ExactArgs(1),
@@ -7,7 +7,7 @@ import (
 	"github.com/Ars2014/ulang/typing"
 )
 
-func Upper(args ...object.Object) object.Object {
+func Upper(env *object.Environment, args ...object.Object) object.Object {
 	if err := typing.Check(
 		"upper", args,
 		typing.ExactArgs(1),
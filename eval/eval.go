@@ -2,20 +2,56 @@ package eval
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"strings"
 
 	"github.com/Ars2014/ulang/ast"
 	"github.com/Ars2014/ulang/builtins"
 	"github.com/Ars2014/ulang/object"
+	"github.com/Ars2014/ulang/token"
 )
 
+const (
+	// maxShift limits the bit count of left shifts so that a single
+	// expression cannot exhaust the host memory.
+	maxShift = 1 << 26
+
+	// maxRepeat limits the size of arrays and strings built by repetition.
+	maxRepeat = 1 << 26
+)
+
+// MaxDepth is the maximum number of nested function calls. Deeper recursion
+// results in an error instead of overflowing the Go stack.
+var MaxDepth = 10000
+
 var (
 	TRUE  = &object.Boolean{Value: true}
 	FALSE = &object.Boolean{Value: false}
 	NULL  = &object.Null{}
 )
 
+func init() {
+	object.Apply = applyFunction
+	object.ApplyOperator = applyOperator
+}
+
+// Run evaluates program in env. It is the entry point for hosts embedding
+// the interpreter: a Go runtime panic raised during evaluation is turned
+// into an internal error object carrying the position it occurred at.
+func Run(program *ast.Program, env *object.Environment) (result object.Object) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = newInternalError(r)
+		}
+	}()
+
+	return Eval(program, env)
+}
+
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	defer annotatePanic(node)
+
 	switch node := node.(type) {
 	case *ast.Program:
 		return evalProgram(node, env)
@@ -23,9 +59,14 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	// Statements
 	case *ast.ExpressionStatement:
 		return Eval(node.Expression, env)
+	case *ast.YieldStatement:
+		return evalYieldStatement(node, env)
 	case *ast.BlockStatement:
 		return evalBlockStatement(node, env)
 	case *ast.ReturnStatement:
+		if node.ReturnValue == nil {
+			return &object.Return{Value: NULL}
+		}
 		val := Eval(node.ReturnValue, env)
 		if isError(val) {
 			return val
@@ -38,6 +79,11 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if isError(right) {
 			return right
 		}
+		if node.Operator == "-" {
+			if result, ok := callMethod(env, right, "__neg__"); ok {
+				return result
+			}
+		}
 		return evalPrefixExpression(node.Operator, right)
 
 	case *ast.InfixExpression:
@@ -45,23 +91,36 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if isError(left) {
 			return left
 		}
+		switch node.Operator {
+		case "&&", "||", "??":
+			return evalLogicalExpression(node.Operator, left, node.Right, env)
+		}
 
 		right := Eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
 
-		return evalInfixExpression(node.Operator, left, right)
+		return applyOperator(env, node.Operator, left, right)
 
 	case *ast.AssignExpression:
 		return evalAssignExpression(node, env)
+	case *ast.CompoundAssignExpression:
+		return evalCompoundAssignExpression(node, env)
 
 	case *ast.IfExpression:
 		return evalIfExpression(node, env)
 	case *ast.ForExpression:
 		return evalForExpression(node, env)
+	case *ast.ForInExpression:
+		return evalForInExpression(node, env)
+	case *ast.StructDeclaration:
+		return evalStructDeclaration(node, env)
 
 	case *ast.IntegerLiteral:
+		if node.Big != nil {
+			return object.NewBigInteger(new(big.Int).Set(node.Big))
+		}
 		return &object.Integer{Value: node.Value}
 	case *ast.FloatLiteral:
 		return &object.Float{Value: node.Value}
@@ -76,20 +135,11 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return evalIdentifier(node, env)
 
 	case *ast.FunctionLiteral:
-		return &object.Function{Parameters: node.Parameters, Env: env, Body: node.Body}
-
-	case *ast.CallExpression:
-		fn := Eval(node.Function, env)
-		if isError(fn) {
-			return fn
-		}
-
-		args := evalExpressions(node.Arguments, env)
-		if len(args) == 1 && isError(args[0]) {
-			return args[0]
-		}
+		return &object.Function{Parameters: node.Parameters, Env: env, Body: node.Body, Generator: node.IsGenerator}
 
-		return applyFunction(fn, args)
+	case *ast.CallExpression, *ast.IndexExpression, *ast.SliceExpression, *ast.SelectorExpression:
+		result, _ := evalChain(node, env)
+		return result
 
 	case *ast.ArrayLiteral:
 		elems := evalExpressions(node.Elements, env)
@@ -98,30 +148,75 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		}
 		return &object.Array{Elements: elems}
 
+	case *ast.TupleLiteral:
+		elems := evalExpressions(node.Elements, env)
+		if len(elems) == 1 && isError(elems[0]) {
+			return elems[0]
+		}
+		return &object.Tuple{Elements: elems}
+
+	case *ast.HashLiteral:
+		return evalHashLiteral(node, env)
+	case *ast.SetLiteral:
+		return evalSetLiteral(node, env)
+	}
+
+	return nil
+}
+
+// evalChain evaluates a chain of call, index, slice and selector
+// expressions. It reports whether an optional selector in the chain found
+// null, which skips the rest of the chain: a?.b.c() is null when a is null.
+func evalChain(node ast.Node, env *object.Environment) (object.Object, bool) {
+	switch node := node.(type) {
+	case *ast.CallExpression:
+		fn, skip := evalChain(node.Function, env)
+		if skip || isError(fn) {
+			return fn, skip
+		}
+
+		args := evalExpressions(node.Arguments, env)
+		if len(args) == 1 && isError(args[0]) {
+			return args[0], false
+		}
+
+		return applyFunction(env, fn, args), false
+
 	case *ast.IndexExpression:
-		left := Eval(node.Left, env)
-		if isError(left) {
-			return left
+		left, skip := evalChain(node.Left, env)
+		if skip || isError(left) {
+			return left, skip
 		}
 		index := Eval(node.Index, env)
 		if isError(index) {
-			return index
+			return index, false
 		}
-		return evalIndexExpression(left, index)
+		return getIndex(env, left, index), false
 
-	case *ast.SelectorExpression:
-		left := Eval(node.Left, env)
-		if isError(left) {
-			return left
+	case *ast.SliceExpression:
+		left, skip := evalChain(node.Left, env)
+		if skip || isError(left) {
+			return left, skip
+		}
+		bounds, err := evalSliceBounds(node, env)
+		if err != nil {
+			return err, false
 		}
+		return evalSliceExpression(left, bounds), false
 
-		return evalSelectorExpression(left, node.Right)
+	case *ast.SelectorExpression:
+		left, skip := evalChain(node.Left, env)
+		if skip || isError(left) {
+			return left, skip
+		}
+		if node.Optional && left.Type() == object.NullType {
+			return NULL, true
+		}
 
-	case *ast.HashLiteral:
-		return evalHashLiteral(node, env)
+		return evalSelectorExpression(left, node.Right), false
 	}
 
-	return nil
+	return Eval(node, env), false
 }
 
 func evalAssignExpression(expr *ast.AssignExpression, env *object.Environment) object.Object {
@@ -130,63 +225,206 @@ func evalAssignExpression(expr *ast.AssignExpression, env *object.Environment) o
 		return value
 	}
 
+	if err := assign(env, expr.Left, value); err != nil {
+		return err
+	}
+	if _, ok := expr.Left.(*ast.Identifier); ok {
+		return value
+	}
+	return NULL
+}
+
+// evalCompoundAssignExpression evaluates the target of a compound
+// assignment once, reads it, combines it with the value and writes the
+// result back. The value of ??= is only evaluated when the target is null.
+func evalCompoundAssignExpression(expr *ast.CompoundAssignExpression, env *object.Environment) object.Object {
+	var (
+		get func() object.Object
+		set func(value object.Object) object.Object
+	)
 	switch e := expr.Left.(type) {
 	case *ast.Identifier:
-		env.Set(e.Value, value)
-		return value
+		get = func() object.Object { return evalIdentifier(e, env) }
+		set = func(value object.Object) object.Object {
+			env.Set(e.Value, value)
+			return nil
+		}
 
 	case *ast.IndexExpression:
 		left := Eval(e.Left, env)
 		if isError(left) {
 			return left
 		}
-		switch obj := left.(type) {
-		case *object.Array:
-			index := Eval(e.Index, env)
-			if isError(index) {
-				return index
-			}
-			if id, ok := index.(*object.Integer); ok {
-				if id.Value >= 0 && int(id.Value) < obj.Len() {
-					obj.Elements[id.Value] = value
-				} else {
-					return newError("array[%d] index out of range: %d", obj.Len(), id.Value)
-				}
-			} else {
-				return newError("cannot index array with %#v", index)
-			}
+		index := Eval(e.Index, env)
+		if isError(index) {
+			return index
+		}
+		get = func() object.Object { return getIndex(env, left, index) }
+		set = func(value object.Object) object.Object { return setIndex(env, left, index, value) }
+
+	case *ast.SelectorExpression:
+		left := Eval(e.Left, env)
+		if isError(left) {
+			return left
+		}
+		get = func() object.Object { return evalSelectorExpression(left, e.Right) }
+		set = func(value object.Object) object.Object { return setMember(left, e.Right, value) }
+
+	default:
+		return newError("expected identifier, index or selector expression got=%T", e)
+	}
 
-		case *object.Hash:
-			key := Eval(e.Index, env)
-			if isError(key) {
-				return key
+	value := get()
+	if isError(value) {
+		return value
+	}
+	if expr.Operator != "??" || value.Type() == object.NullType {
+		var right object.Object = &object.Integer{Value: 1}
+		if expr.Right != nil {
+			right = Eval(expr.Right, env)
+			if isError(right) {
+				return right
 			}
-			if hashKey, ok := key.(object.Hashable); ok {
-				hashed := hashKey.HashKey()
-				obj.Pairs[hashed] = object.HashPair{Key: key, Value: value}
-			} else {
-				return newError("cannot index hash with %T", key)
+		}
+		if expr.Operator == "??" {
+			value = right
+		} else {
+			value = applyOperator(env, expr.Operator, value, right)
+			if isError(value) {
+				return value
 			}
+		}
+		if err := set(value); err != nil {
+			return err
+		}
+	}
 
-		default:
-			return newError("object type %T does not support item assignment", obj)
+	if _, ok := expr.Left.(*ast.Identifier); ok {
+		return value
+	}
+	return NULL
+}
+
+// assign assigns a value to a target, which is a name, an index, slice or
+// selector expression or a pattern. It returns an error or nil.
+func assign(env *object.Environment, target ast.Expression, value object.Object) object.Object {
+	switch e := target.(type) {
+	case *ast.Identifier:
+		env.Set(e.Value, value)
+
+	case *ast.IndexExpression:
+		left := Eval(e.Left, env)
+		if isError(left) {
+			return left
+		}
+		index := Eval(e.Index, env)
+		if isError(index) {
+			return index
+		}
+		return setIndex(env, left, index, value)
+
+	case *ast.SliceExpression:
+		left := Eval(e.Left, env)
+		if isError(left) {
+			return left
+		}
+		bounds, err := evalSliceBounds(e, env)
+		if err != nil {
+			return err
+		}
+		array, ok := left.(*object.Array)
+		if !ok {
+			return newError("TypeError: %s does not support slice assignment", left.Type())
+		}
+		if err := evalSliceAssignment(env, array, bounds, value); err != nil {
+			return err
 		}
 
 	case *ast.SelectorExpression:
 		left := Eval(e.Left, env)
-		if hash, ok := left.(*object.Hash); ok {
-			key := &object.String{Value: e.Right.Value}
-			hashed := key.HashKey()
-			hash.Pairs[hashed] = object.HashPair{Key: key, Value: value}
+		if isError(left) {
+			return left
+		}
+		return setMember(left, e.Right, value)
+
+	case *ast.ArrayLiteral:
+		return destructureSequence(env, e.Elements, value)
+	case *ast.TupleLiteral:
+		return destructureSequence(env, e.Elements, value)
+	case *ast.SetLiteral:
+		return destructureNames(env, e.Elements, value)
+	case *ast.HashLiteral:
+		return destructureKeys(env, e.Pairs, value)
+
+	default:
+		return newError("expected identifier, index, slice or selector expression or pattern got=%T", e)
+	}
+
+	return nil
+}
+
+// getIndex returns left[index].
+func getIndex(env *object.Environment, left, index object.Object) object.Object {
+	if result, ok := callMethod(env, left, "__getitem__", index); ok {
+		return result
+	}
+	return evalIndexExpression(left, index)
+}
+
+// setIndex assigns left[index] a value. It returns an error or nil.
+func setIndex(env *object.Environment, left, index, value object.Object) object.Object {
+	switch obj := left.(type) {
+	case *object.Array:
+		if id, ok := index.(*object.Integer); ok {
+			if idx, ok := normalizeIndex(id, obj.Len()); ok {
+				obj.Elements[idx] = value
+			} else {
+				return newError("IndexError: array[%d] index out of range: %s", obj.Len(), id.Inspect())
+			}
+		} else {
+			return newError("cannot index array with %#v", index)
+		}
+
+	case *object.Hash:
+		if object.IsHashable(index) {
+			obj.Set(index, value)
 		} else {
-			return newError("object type %T does not support item assignment", left)
+			return newError("unusable as hash key: %s", index.Type())
+		}
+
+	case *object.Tuple:
+		return newError("TypeError: tuple does not support item assignment")
+
+	case *object.Instance:
+		result, ok := callMethod(env, obj, "__setitem__", index, value)
+		if !ok {
+			return newError("TypeError: %s does not support item assignment", obj.Type())
+		}
+		if isError(result) {
+			return result
 		}
 
 	default:
-		return newError("expected identifier, index expression or selector expression got=%T", e)
+		return newError("object type %T does not support item assignment", obj)
 	}
 
-	return NULL
+	return nil
+}
+
+// setMember assigns a key of a hash or a field of an instance a value. It
+// returns an error or nil.
+func setMember(left object.Object, name *ast.Identifier, value object.Object) object.Object {
+	if hash, ok := left.(*object.Hash); ok {
+		hash.Set(&object.String{Value: name.Value}, value)
+	} else if instance, ok := left.(*object.Instance); ok {
+		if !instance.Set(name.Value, value) {
+			return newError("AttributeError: %s has no field %s", instance.Type(), name.Value)
+		}
+	} else {
+		return newError("object type %T does not support item assignment", left)
+	}
+
+	return nil
 }
 
 func evalProgram(program *ast.Program, env *object.Environment) object.Object {
@@ -220,17 +458,30 @@ func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) obje
 		}
 	}
 
+	if result == nil {
+		return NULL
+	}
+
 	return result
 }
 
 func evalPrefixExpression(operator string, right object.Object) object.Object {
+	if operator == "!" {
+		return fromNativeBoolean(!right.Bool())
+	}
+
 	switch r := right.(type) {
 	case *object.Integer:
 		return evalIntegerPrefixOperatorExpression(operator, r)
 	case *object.Float:
 		return evalFloatPrefixOperatorExpression(operator, r)
 	case *object.Boolean:
-		return evalBooleanPrefixOperatorExpression(operator, r)
+		return newError("unknown operator: %s%s", operator, right.Type())
+	case *object.Duration:
+		if operator == "-" {
+			return multiplyDuration(r.Value, object.NewInteger(-1))
+		}
+		return newError("unknown operator: %s%s", operator, right.Type())
 	default:
 		return newError("%s doesn't support prefix operators", right.Type())
 	}
@@ -242,11 +493,15 @@ func evalIntegerPrefixOperatorExpression(operator string, right *object.Integer)
 	switch operator {
 	case "+":
 		return right
-	case "!":
-		return fromNativeBoolean(!right.Bool())
 	case "~":
+		if right.IsBig() {
+			return object.NewBigInteger(new(big.Int).Not(right.Big))
+		}
 		return &object.Integer{Value: ^value}
 	case "-":
+		if right.IsBig() || value == math.MinInt64 {
+			return object.NewBigInteger(new(big.Int).Neg(right.BigValue()))
+		}
 		return &object.Integer{Value: -value}
 	default:
 		return newError("unknown operator: %s%s", operator, right.Type())
@@ -259,8 +514,6 @@ func evalFloatPrefixOperatorExpression(operator string, right *object.Float) obj
 	switch operator {
 	case "+":
 		return right
-	case "!":
-		return fromNativeBoolean(!right.Bool())
 	case "-":
 		return &object.Float{Value: -value}
 	default:
@@ -268,89 +521,98 @@ func evalFloatPrefixOperatorExpression(operator string, right *object.Float) obj
 	}
 }
 
-func evalBooleanPrefixOperatorExpression(operator string, right *object.Boolean) object.Object {
+// evalLogicalExpression evaluates the right operand of &&, || and ?? only
+// when the left one doesn't decide the result, and returns the operand that
+// does: 0 || "none" is "none" and null ?? 1 is 1.
+func evalLogicalExpression(operator string, left object.Object, right ast.Expression, env *object.Environment) object.Object {
 	switch operator {
-	case "!":
-		return fromNativeBoolean(!right.Bool())
-	default:
-		return newError("unknown operator: %s%s", operator, right.Type())
+	case "&&":
+		if !left.Bool() {
+			return left
+		}
+	case "||":
+		if left.Bool() {
+			return left
+		}
+	case "??":
+		if left.Type() != object.NullType {
+			return left
+		}
+	}
+	return Eval(right, env)
+}
+
+// applyOperator evaluates a binary operator, calling the protocol method of
+// an instance operand if it declares one.
+func applyOperator(env *object.Environment, operator string, left, right object.Object) object.Object {
+	if result, ok := evalOperatorMethod(env, operator, left, right); ok {
+		return result
+	}
+	var result object.Object
+	if err := env.Catch(func() { result = evalInfixExpression(operator, left, right) }); err != nil {
+		return err
 	}
+	return result
 }
 
 func evalInfixExpression(operator string, left object.Object, right object.Object) object.Object {
 	switch {
 	case operator == "+" && left.Type() == object.HashType && right.Type() == object.HashType:
-		leftVal := left.(*object.Hash).Pairs
-		rightVal := right.(*object.Hash).Pairs
-		pairs := make(map[object.HashKey]object.HashPair)
-		for k, v := range leftVal {
-			pairs[k] = v
-		}
-		for k, v := range rightVal {
-			pairs[k] = v
+		hash := left.(*object.Hash).Copy()
+		for _, pair := range right.(*object.Hash).Pairs() {
+			hash.Set(pair.Key, pair.Value)
 		}
-		return &object.Hash{Pairs: pairs}
+		return hash
 
 	case operator == "+" && left.Type() == object.ArrayType && right.Type() == object.ArrayType:
 		leftVal := left.(*object.Array).Elements
 		rightVal := right.(*object.Array).Elements
-		elements := make([]object.Object, len(leftVal)+len(rightVal))
-		elements = append(leftVal, rightVal...)
+		elements := make([]object.Object, 0, len(leftVal)+len(rightVal))
+		elements = append(append(elements, leftVal...), rightVal...)
 		return &object.Array{Elements: elements}
 
+	case operator == "+" && left.Type() == object.TupleType && right.Type() == object.TupleType:
+		leftVal := left.(*object.Tuple).Elements
+		rightVal := right.(*object.Tuple).Elements
+		elements := make([]object.Object, 0, len(leftVal)+len(rightVal))
+		elements = append(append(elements, leftVal...), rightVal...)
+		return &object.Tuple{Elements: elements}
+
 	case operator == "*" && left.Type() == object.ArrayType && right.Type() == object.IntegerType:
-		leftVal := left.(*object.Array).Elements
-		rightVal := right.(*object.Integer).Value
-		elements := leftVal
-		for i := rightVal; i > 1; i-- {
-			elements = append(elements, leftVal...)
-		}
-		return &object.Array{Elements: elements}
+		return repeatArray(left.(*object.Array), right.(*object.Integer))
 
 	case operator == "*" && left.Type() == object.IntegerType && right.Type() == object.ArrayType:
-		rightVal := right.(*object.Array).Elements
-		leftVal := left.(*object.Integer).Value
-		elements := rightVal
-		for i := leftVal; i > 1; i-- {
-			elements = append(elements, rightVal...)
-		}
-		return &object.Array{Elements: elements}
+		return repeatArray(right.(*object.Array), left.(*object.Integer))
 
 	case operator == "*" && left.Type() == object.StringType && right.Type() == object.IntegerType:
-		leftVal := left.(*object.String).Value
-		rightVal := right.(*object.Integer).Value
-		return &object.String{Value: strings.Repeat(leftVal, int(rightVal))}
+		return repeatString(left.(*object.String), right.(*object.Integer))
 
 	case operator == "*" && left.Type() == object.IntegerType && right.Type() == object.StringType:
-		rightVal := right.(*object.String).Value
-		leftVal := left.(*object.Integer).Value
-		return &object.String{Value: strings.Repeat(rightVal, int(leftVal))}
+		return repeatString(right.(*object.String), left.(*object.Integer))
+
+	case left.Type() == object.SetType && right.Type() == object.SetType:
+		return evalSetInfixExpression(operator, left.(*object.Set), right.(*object.Set))
+
+	case isComparison(operator):
+		return evalComparisonExpression(operator, left, right)
+
+	case isTemporal(left) || isTemporal(right):
+		return evalTemporalInfixExpression(operator, left, right)
+
+	case isBitwise(operator) && isNumber(left) && isNumber(right) &&
+		(left.Type() == object.FloatType || right.Type() == object.FloatType):
+		return newError("TypeError: unsupported operand types for %s: %s and %s", operator, left.Type(), right.Type())
 
 	case left.Type() == object.IntegerType && right.Type() == object.FloatType:
-		fLeft := &object.Float{Value: float64(left.(*object.Integer).Value)}
+		fLeft := &object.Float{Value: left.(*object.Integer).Float64()}
 		return evalFloatInfixExpression(operator, fLeft, right.(*object.Float))
 
 	case left.Type() == object.FloatType && right.Type() == object.IntegerType:
-		fRight := &object.Float{Value: float64(right.(*object.Integer).Value)}
+		fRight := &object.Float{Value: right.(*object.Integer).Float64()}
 		return evalFloatInfixExpression(operator, left.(*object.Float), fRight)
 
-	case operator == "==":
-		return fromNativeBoolean(left.(object.Comparable).Compare(right) == 0)
-	case operator == "!=":
-		return fromNativeBoolean(left.(object.Comparable).Compare(right) != 0)
-	case operator == "<=":
-		return fromNativeBoolean(left.(object.Comparable).Compare(right) < 1)
-	case operator == ">=":
-		return fromNativeBoolean(left.(object.Comparable).Compare(right) > -1)
-	case operator == "<":
-		return fromNativeBoolean(left.(object.Comparable).Compare(right) == -1)
-	case operator == ">":
-		return fromNativeBoolean(left.(object.Comparable).Compare(right) == 1)
-
 	case left.Type() == right.Type():
 		switch left.Type() {
-		case object.BooleanType:
-			return evalBooleanInfixExpression(operator, left.(*object.Boolean), right.(*object.Boolean))
 		case object.IntegerType:
 			return evalIntegerInfixExpression(operator, left.(*object.Integer), right.(*object.Integer))
 		case object.FloatType:
@@ -366,38 +628,141 @@ func evalInfixExpression(operator string, left object.Object, right object.Objec
 
 }
 
-func evalBooleanInfixExpression(operator string, left *object.Boolean, right *object.Boolean) object.Object {
-	leftVal := left.Value
-	rightVal := right.Value
+func isComparison(operator string) bool {
+	switch operator {
+	case "==", "!=", "<=", ">=", "<", ">":
+		return true
+	default:
+		return false
+	}
+}
 
+func isBitwise(operator string) bool {
 	switch operator {
-	case "&&":
-		return fromNativeBoolean(leftVal && rightVal)
-	case "||":
-		return fromNativeBoolean(leftVal || rightVal)
+	case "|", "^", "&", "<<", ">>":
+		return true
 	default:
-		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+		return false
 	}
 }
 
+func isNumber(obj object.Object) bool {
+	return obj.Type() == object.IntegerType || obj.Type() == object.FloatType
+}
+
+func isNaN(obj object.Object) bool {
+	f, ok := obj.(*object.Float)
+	return ok && math.IsNaN(f.Value)
+}
+
+func evalComparisonExpression(operator string, left object.Object, right object.Object) object.Object {
+	// NaN is unordered: it compares unequal to everything, itself included.
+	if isNaN(left) || isNaN(right) {
+		return fromNativeBoolean(operator == "!=")
+	}
+
+	cmp, ok := left.(object.Comparable)
+	if !ok {
+		switch operator {
+		case "==":
+			return fromNativeBoolean(left == right)
+		case "!=":
+			return fromNativeBoolean(left != right)
+		default:
+			return newError("TypeError: %s not supported between %s and %s", operator, left.Type(), right.Type())
+		}
+	}
+
+	switch operator {
+	case "==":
+		return fromNativeBoolean(cmp.Compare(right) == 0)
+	case "!=":
+		return fromNativeBoolean(cmp.Compare(right) != 0)
+	case "<=":
+		return fromNativeBoolean(cmp.Compare(right) < 1)
+	case ">=":
+		return fromNativeBoolean(cmp.Compare(right) > -1)
+	case "<":
+		return fromNativeBoolean(cmp.Compare(right) == -1)
+	default:
+		return fromNativeBoolean(cmp.Compare(right) == 1)
+	}
+}
+
+func repeatArray(array *object.Array, count *object.Integer) object.Object {
+	if count.IsBig() {
+		return newError("OverflowError: repeat count too large: %s", count.Inspect())
+	}
+
+	if count.Value > 0 && int64(len(array.Elements)) > maxRepeat/count.Value {
+		return newError("OverflowError: repeat count too large: %d", count.Value)
+	}
+
+	elements := make([]object.Object, 0)
+	for i := count.Value; i > 0; i-- {
+		elements = append(elements, array.Elements...)
+	}
+	return &object.Array{Elements: elements}
+}
+
+func repeatString(str *object.String, count *object.Integer) object.Object {
+	if count.IsBig() {
+		return newError("OverflowError: repeat count too large: %s", count.Inspect())
+	}
+
+	if count.Value <= 0 {
+		return &object.String{Value: ""}
+	}
+	if int64(len(str.Value)) > maxRepeat/count.Value {
+		return newError("OverflowError: repeat count too large: %d", count.Value)
+	}
+	return &object.String{Value: strings.Repeat(str.Value, int(count.Value))}
+}
+
 func evalIntegerInfixExpression(operator string, left *object.Integer, right *object.Integer) object.Object {
+	if left.IsBig() || right.IsBig() {
+		return evalBigIntegerInfixExpression(operator, left, right)
+	}
+
 	leftVal := left.Value
 	rightVal := right.Value
 
 	switch operator {
 	case "+":
-		return &object.Integer{Value: leftVal + rightVal}
+		if result := leftVal + rightVal; (result > leftVal) == (rightVal > 0) {
+			return &object.Integer{Value: result}
+		}
+		return evalBigIntegerInfixExpression(operator, left, right)
 	case "-":
-		return &object.Integer{Value: leftVal - rightVal}
+		if result := leftVal - rightVal; (result < leftVal) == (rightVal > 0) {
+			return &object.Integer{Value: result}
+		}
+		return evalBigIntegerInfixExpression(operator, left, right)
 	case "*":
-		return &object.Integer{Value: leftVal * rightVal}
+		if leftVal == 0 || rightVal == 0 {
+			return &object.Integer{Value: 0}
+		}
+		result := leftVal * rightVal
+		if result/rightVal == leftVal && !(leftVal == -1 && rightVal == math.MinInt64) && !(rightVal == -1 && leftVal == math.MinInt64) {
+			return &object.Integer{Value: result}
+		}
+		return evalBigIntegerInfixExpression(operator, left, right)
 	case "/":
 		if rightVal == 0 {
 			return newError("division by zero: %d %s %d", leftVal, operator, rightVal)
 		}
 		return &object.Float{Value: float64(leftVal) / float64(rightVal)}
-	case "%":
-		return &object.Integer{Value: leftVal % rightVal}
+	case "//", "%":
+		if rightVal == 0 {
+			return newError("division by zero: %d %s %d", leftVal, operator, rightVal)
+		}
+		quo, rem := left.FloorDivMod(right)
+		if operator == "//" {
+			return quo
+		}
+		return rem
+	case "**":
+		return builtins.Pow(nil, left, right)
 	case "|":
 		return &object.Integer{Value: leftVal | rightVal}
 	case "^":
@@ -405,21 +770,75 @@ func evalIntegerInfixExpression(operator string, left *object.Integer, right *ob
 	case "&":
 		return &object.Integer{Value: leftVal & rightVal}
 	case "<<":
-		return &object.Integer{Value: leftVal << rightVal}
+		if rightVal < 0 {
+			return newError("ValueError: negative shift count: %d", rightVal)
+		}
+		if rightVal < 63 {
+			if result := leftVal << rightVal; result>>rightVal == leftVal {
+				return &object.Integer{Value: result}
+			}
+		}
+		return evalBigIntegerInfixExpression(operator, left, right)
 	case ">>":
+		if rightVal < 0 {
+			return newError("ValueError: negative shift count: %d", rightVal)
+		}
 		return &object.Integer{Value: leftVal >> rightVal}
-	case "==":
-		return fromNativeBoolean(left.Compare(right) == 0)
-	case "!=":
-		return fromNativeBoolean(left.Compare(right) != 0)
-	case "<=":
-		return fromNativeBoolean(left.Compare(right) < 1)
-	case ">=":
-		return fromNativeBoolean(left.Compare(right) > -1)
-	case "<":
-		return fromNativeBoolean(left.Compare(right) == -1)
-	case ">":
-		return fromNativeBoolean(left.Compare(right) == 1)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func evalBigIntegerInfixExpression(operator string, left *object.Integer, right *object.Integer) object.Object {
+	leftVal := left.BigValue()
+	rightVal := right.BigValue()
+
+	switch operator {
+	case "+":
+		return object.NewBigInteger(leftVal.Add(leftVal, rightVal))
+	case "-":
+		return object.NewBigInteger(leftVal.Sub(leftVal, rightVal))
+	case "*":
+		return object.NewBigInteger(leftVal.Mul(leftVal, rightVal))
+	case "/":
+		if rightVal.Sign() == 0 {
+			return newError("division by zero: %s %s %s", leftVal, operator, rightVal)
+		}
+		value, _ := new(big.Rat).SetFrac(leftVal, rightVal).Float64()
+		return &object.Float{Value: value}
+	case "//", "%":
+		if rightVal.Sign() == 0 {
+			return newError("division by zero: %s %s %s", leftVal, operator, rightVal)
+		}
+		quo, rem := left.FloorDivMod(right)
+		if operator == "//" {
+			return quo
+		}
+		return rem
+	case "**":
+		return builtins.Pow(nil, left, right)
+	case "|":
+		return object.NewBigInteger(leftVal.Or(leftVal, rightVal))
+	case "^":
+		return object.NewBigInteger(leftVal.Xor(leftVal, rightVal))
+	case "&":
+		return object.NewBigInteger(leftVal.And(leftVal, rightVal))
+	case "<<":
+		if rightVal.Sign() < 0 {
+			return newError("ValueError: negative shift count: %s", rightVal)
+		}
+		if !rightVal.IsUint64() || rightVal.Uint64() > maxShift {
+			return newError("OverflowError: shift count too large: %s", rightVal)
+		}
+		return object.NewBigInteger(leftVal.Lsh(leftVal, uint(rightVal.Uint64())))
+	case ">>":
+		if rightVal.Sign() < 0 {
+			return newError("ValueError: negative shift count: %s", rightVal)
+		}
+		if !rightVal.IsInt64() {
+			return object.NewInteger(int64(leftVal.Sign() >> 1))
+		}
+		return object.NewBigInteger(leftVal.Rsh(leftVal, uint(rightVal.Int64())))
 	default:
 		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
@@ -441,18 +860,22 @@ func evalFloatInfixExpression(operator string, left *object.Float, right *object
 			return newError("division by zero: %f %s %f", leftVal, operator, rightVal)
 		}
 		return &object.Float{Value: leftVal / rightVal}
-	case "==":
-		return fromNativeBoolean(left.Compare(right) == 0)
-	case "!=":
-		return fromNativeBoolean(left.Compare(right) != 0)
-	case "<=":
-		return fromNativeBoolean(left.Compare(right) < 1)
-	case ">=":
-		return fromNativeBoolean(left.Compare(right) > -1)
-	case "<":
-		return fromNativeBoolean(left.Compare(right) == -1)
-	case ">":
-		return fromNativeBoolean(left.Compare(right) == 1)
+	case "//":
+		if rightVal == 0.0 {
+			return newError("division by zero: %f %s %f", leftVal, operator, rightVal)
+		}
+		return &object.Float{Value: math.Floor(leftVal / rightVal)}
+	case "%":
+		if rightVal == 0.0 {
+			return newError("division by zero: %f %s %f", leftVal, operator, rightVal)
+		}
+		rem := math.Mod(leftVal, rightVal)
+		if rem != 0 && (rem < 0) != (rightVal < 0) {
+			rem += rightVal
+		}
+		return &object.Float{Value: rem}
+	case "**":
+		return builtins.Pow(nil, left, right)
 	default:
 		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
 	}
@@ -515,13 +938,58 @@ func evalForExpression(expr *ast.ForExpression, env *object.Environment) object.
 			return cond
 		}
 
-		if cond.Bool() {
-			result = Eval(expr.Consequence, env)
-		} else {
+		if !cond.Bool() {
 			break
 		}
 
-		Eval(counter, env)
+		result = Eval(expr.Consequence, env)
+		if rt := result.Type(); rt == object.ReturnType || rt == object.ErrorType {
+			return result
+		}
+
+		if count := Eval(counter, env); isError(count) {
+			return count
+		}
+	}
+
+	if result != nil {
+		return result
+	}
+
+	return NULL
+}
+
+func evalForInExpression(expr *ast.ForInExpression, env *object.Environment) object.Object {
+	iterable := Eval(expr.Iterable, env)
+	if isError(iterable) {
+		return iterable
+	}
+
+	obj, err := iterableOf(env, iterable)
+	if err != nil {
+		return err
+	}
+
+	var result object.Object
+	iter := obj.Iter()
+	for {
+		value, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if isError(value) {
+			return value
+		}
+		if err := assign(env, expr.Variable, value); err != nil {
+			object.CloseIterator(iter)
+			return err
+		}
+
+		result = Eval(expr.Consequence, env)
+		if rt := result.Type(); rt == object.ReturnType || rt == object.ErrorType {
+			object.CloseIterator(iter)
+			return result
+		}
 	}
 
 	if result != nil {
@@ -531,6 +999,44 @@ func evalForExpression(expr *ast.ForExpression, env *object.Environment) object.
 	return NULL
 }
 
+// iterableOf returns obj as an iterable, calling its __iter__ method if it
+// has one.
+func iterableOf(env *object.Environment, obj object.Object) (object.Iterable, object.Object) {
+	if result, ok := callMethod(env, obj, "__iter__"); ok {
+		if isError(result) {
+			return nil, result
+		}
+		obj = result
+	}
+
+	iterable, ok := obj.(object.Iterable)
+	if !ok {
+		return nil, newError("TypeError: %s is not iterable", obj.Type())
+	}
+	return iterable, nil
+}
+
+// elementsOf returns the elements of an iterable.
+func elementsOf(env *object.Environment, obj object.Object) ([]object.Object, object.Object) {
+	iterable, err := iterableOf(env, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := []object.Object{}
+	iter := iterable.Iter()
+	for {
+		el, ok := iter.Next()
+		if !ok {
+			return elements, nil
+		}
+		if isError(el) {
+			return nil, el
+		}
+		elements = append(elements, el)
+	}
+}
+
 func evalIdentifier(ident *ast.Identifier, env *object.Environment) object.Object {
 	if val, ok := env.Get(ident.Value); ok {
 		return val
@@ -540,6 +1046,10 @@ func evalIdentifier(ident *ast.Identifier, env *object.Environment) object.Objec
 		return builtin
 	}
 
+	if module, ok := builtins.Modules[ident.Value]; ok {
+		return env.Module(ident.Value, module)
+	}
+
 	return newError("identifier not found: " + ident.Value)
 }
 
@@ -547,41 +1057,104 @@ func evalExpressions(list ast.ExpressionList, env *object.Environment) []object.
 	var result []object.Object
 
 	for _, e := range list {
+		spread, isSpread := e.(*ast.SpreadElement)
+		if isSpread {
+			e = spread.Value
+		}
 		evaluated := Eval(e, env)
 		if isError(evaluated) {
 			return []object.Object{evaluated}
 		}
-		result = append(result, evaluated)
+		if !isSpread {
+			result = append(result, evaluated)
+			continue
+		}
+		elements, err := elementsOf(env, evaluated)
+		if err != nil {
+			return []object.Object{err}
+		}
+		result = append(result, elements...)
 	}
 
 	return result
 }
 
-func applyFunction(fn object.Object, args []object.Object) object.Object {
+func applyFunction(env *object.Environment, fn object.Object, args []object.Object) object.Object {
 	switch fn := fn.(type) {
 	case *object.Function:
-		env := extendFunctionEnv(fn, args)
-		return unwrapReturnValue(Eval(fn.Body, env))
+		return callFunction(env, fn, nil, args)
 
-	case *object.Builtin:
-		if result := fn.Fn(args...); result != nil {
+	case *object.BoundMethod:
+		return callFunction(env, fn.Method, fn.Self, args)
+
+	case *object.Struct:
+		return construct(env, fn, args)
+
+	case *object.Instance:
+		if result, ok := callMethod(env, fn, "__call__", args...); ok {
 			return result
 		}
-		return NULL
+		return newError("not a function: %s", fn.Type())
+
+	case *object.Builtin:
+		return callBuiltin(env, fn, args)
+
+	case *object.BuiltinMethod:
+		return callBuiltin(env, fn.Builtin, append([]object.Object{fn.Self}, args...))
 
 	default:
 		return newError("not a function: %s", fn.Type())
 	}
 }
 
-func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Environment {
-	env := fn.Env.NewChild()
+// callBuiltin calls fn, returning an error raised by a protocol method it
+// calls, such as __eq__ when it compares instances.
+func callBuiltin(env *object.Environment, fn *object.Builtin, args []object.Object) object.Object {
+	var result object.Object
+	if err := env.Catch(func() { result = fn.Fn(env, args...) }); err != nil {
+		return err
+	}
+	if result == nil {
+		return NULL
+	}
+	return result
+}
+
+// callFunction calls fn with self bound in its environment unless it is nil.
+func callFunction(env *object.Environment, fn *object.Function, self object.Object, args []object.Object) object.Object {
+	if len(args) != len(fn.Parameters) {
+		return newError(
+			"TypeError: fn() takes exactly %d argument (%d given)",
+			len(fn.Parameters), len(args),
+		)
+	}
+	if env.Depth() >= MaxDepth {
+		return newError("RecursionError: maximum recursion depth exceeded")
+	}
+	fnEnv, err := extendFunctionEnv(env, fn, args)
+	if err != nil {
+		return err
+	}
+	if self != nil {
+		fnEnv.Set("self", self)
+	}
+	if fn.Generator {
+		return newGenerator(fn, fnEnv)
+	}
+	defer fnEnv.Restore(fnEnv.Activate())
+	return unwrapReturnValue(Eval(fn.Body, fnEnv))
+}
+
+func extendFunctionEnv(caller *object.Environment, fn *object.Function, args []object.Object) (*object.Environment, object.Object) {
+	env := fn.Env.NewFrame(caller)
 
 	for paramId, param := range fn.Parameters {
-		env.Set(param.Value, args[paramId])
+		if err := assign(env, param, args[paramId]); err != nil {
+			return nil, err
+		}
 	}
 
-	return env
+	return env, nil
 }
 
 func unwrapReturnValue(obj object.Object) object.Object {
@@ -597,7 +1170,9 @@ func evalIndexExpression(left object.Object, index object.Object) object.Object
 	case left.Type() == object.StringType && index.Type() == object.IntegerType:
 		return evalStringIndexExpression(left.(*object.String), index.(*object.Integer))
 	case left.Type() == object.ArrayType && index.Type() == object.IntegerType:
-		return evalArrayIndexExpression(left.(*object.Array), index.(*object.Integer))
+		return evalArrayIndexExpression(left.Type(), left.(*object.Array).Elements, index.(*object.Integer))
+	case left.Type() == object.TupleType && index.Type() == object.IntegerType:
+		return evalArrayIndexExpression(left.Type(), left.(*object.Tuple).Elements, index.(*object.Integer))
 	case left.Type() == object.HashType:
 		return evalHashIndexExpression(left.(*object.Hash), index)
 	default:
@@ -606,82 +1181,141 @@ func evalIndexExpression(left object.Object, index object.Object) object.Object
 }
 
 func evalStringIndexExpression(str *object.String, index *object.Integer) object.Object {
-	idx := index.Value
-	max := int64(len(str.Value) - 1)
-
-	if idx < 0 || idx > max {
-		return &object.String{Value: ""}
+	runes := []rune(str.Value)
+	idx, ok := normalizeIndex(index, len(runes))
+	if !ok {
+		return indexError(str.Type(), &object.String{Value: ""})
 	}
 
-	return &object.String{Value: string(str.Value[idx])}
+	return &object.String{Value: string(runes[idx])}
 }
 
-func evalArrayIndexExpression(array *object.Array, index *object.Integer) object.Object {
-	idx := index.Value
-	max := int64(len(array.Elements) - 1)
-
-	if idx < 0 || idx > max {
-		return NULL
+func evalArrayIndexExpression(typ object.Type, elements []object.Object, index *object.Integer) object.Object {
+	idx, ok := normalizeIndex(index, len(elements))
+	if !ok {
+		return indexError(typ, NULL)
 	}
 
-	return array.Elements[idx]
+	return elements[idx]
 }
 
 func evalHashIndexExpression(hash *object.Hash, index object.Object) object.Object {
-	key, ok := index.(object.Hashable)
-	if !ok {
+	if !object.IsHashable(index) {
 		return newError("unusable as hash key: %s", index.Type())
 	}
 
-	pair, ok := hash.Pairs[key.HashKey()]
+	value, ok := hash.Get(index)
 	if !ok {
 		return NULL
 	}
 
-	return pair.Value
+	return value
 }
 
 func evalSelectorExpression(left object.Object, right *ast.Identifier) object.Object {
-	hash, ok := left.(*object.Hash)
-	if !ok {
-		return newError("%s does not support selection", left.Type())
+	if instance, ok := left.(*object.Instance); ok {
+		value, ok := instance.Get(right.Value)
+		if !ok {
+			return newError("AttributeError: %s has no member %s", instance.Type(), right.Value)
+		}
+		return value
 	}
 
-	index := &object.String{Value: right.Value}
-	key := index.HashKey()
+	// Keys of a hash take priority over its methods.
+	hash, isHash := left.(*object.Hash)
+	if isHash {
+		if value, ok := hash.Get(&object.String{Value: right.Value}); ok {
+			return value
+		}
+	}
 
-	pair, ok := hash.Pairs[key]
-	if !ok {
-		return NULL
+	if value, ok := builtins.Attribute(left, right.Value); ok {
+		return value
+	}
+	if method, ok := builtins.Method(left, right.Value); ok {
+		return method
 	}
 
-	return pair.Value
+	switch {
+	case isHash:
+		return NULL
+	case builtins.Methods[left.Type()] == nil:
+		return newError("%s does not support selection", left.Type())
+	default:
+		return newError("AttributeError: %s has no member %s", left.Type(), right.Value)
+	}
 }
 
 func evalHashLiteral(node *ast.HashLiteral, env *object.Environment) object.Object {
-	pairs := make(map[object.HashKey]object.HashPair)
+	hash := object.NewHash()
 
-	for keyNode, valueNode := range node.Pairs {
-		key := Eval(keyNode, env)
+	for _, pair := range node.Pairs {
+		key := Eval(pair.Key, env)
 		if isError(key) {
 			return key
 		}
 
-		hashKey, ok := key.(object.Hashable)
-		if !ok {
+		if !object.IsHashable(key) {
 			return newError("unusable as hash key: %s", key.Type())
 		}
 
-		value := Eval(valueNode, env)
+		value := Eval(pair.Value, env)
+		if isError(value) {
+			return value
+		}
+
+		hash.Set(key, value)
+	}
+
+	return hash
+}
+
+func evalSetLiteral(node *ast.SetLiteral, env *object.Environment) object.Object {
+	set := object.NewSet()
+
+	for _, el := range node.Elements {
+		value := Eval(el, env)
 		if isError(value) {
 			return value
 		}
 
-		hashed := hashKey.HashKey()
-		pairs[hashed] = object.HashPair{Key: key, Value: value}
+		if !object.IsHashable(value) {
+			return newError("unusable as set element: %s", value.Type())
+		}
+
+		set.Add(value)
 	}
 
-	return &object.Hash{Pairs: pairs}
+	return set
+}
+
+// evalSetInfixExpression implements set algebra and orders sets by
+// inclusion: a <= b when a is a subset of b.
+func evalSetInfixExpression(operator string, left, right *object.Set) object.Object {
+	switch operator {
+	case "|":
+		return left.Union(right)
+	case "&":
+		return left.Intersection(right)
+	case "-":
+		return left.Difference(right)
+	case "^":
+		return left.SymmetricDifference(right)
+	case "==":
+		return fromNativeBoolean(left.Compare(right) == 0)
+	case "!=":
+		return fromNativeBoolean(left.Compare(right) != 0)
+	case "<=":
+		return fromNativeBoolean(left.IsSubset(right))
+	case ">=":
+		return fromNativeBoolean(right.IsSubset(left))
+	case "<":
+		return fromNativeBoolean(left.Len() < right.Len() && left.IsSubset(right))
+	case ">":
+		return fromNativeBoolean(right.Len() < left.Len() && right.IsSubset(left))
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
 }
 
 func fromNativeBoolean(input bool) *object.Boolean {
@@ -692,6 +1326,33 @@ func fromNativeBoolean(input bool) *object.Boolean {
 	return FALSE
 }
 
+// runtimePanic records where in the program a Go panic was raised.
+type runtimePanic struct {
+	pos   token.Pos
+	value interface{}
+}
+
+func (p *runtimePanic) String() string {
+	return fmt.Sprintf("%v at %d:%d", p.value, p.pos.Line, p.pos.Column)
+}
+
+// annotatePanic is deferred by Eval and attaches the position of the
+// innermost node to a panic travelling up to Run.
+func annotatePanic(node ast.Node) {
+	if r := recover(); r != nil {
+		if _, ok := r.(*runtimePanic); !ok {
+			if n, ok := node.(interface{ Pos() token.Pos }); ok {
+				r = &runtimePanic{pos: n.Pos(), value: r}
+			}
+		}
+		panic(r)
+	}
+}
+
+func newInternalError(r interface{}) *object.Error {
+	return newError("InternalError: %v", r)
+}
+
 func newError(format string, a ...interface{}) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(format, a...)}
 }
@@ -2,11 +2,20 @@ package eval
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Ars2014/ulang/ast"
+	"github.com/Ars2014/ulang/builtins"
 	"github.com/Ars2014/ulang/lexer"
 	"github.com/Ars2014/ulang/object"
 	"github.com/Ars2014/ulang/parser"
@@ -68,6 +77,19 @@ func testEval(input string) object.Object {
 	return Eval(program.(*ast.Program), env)
 }
 
+func testEvalEnv(t *testing.T, input string, env *object.Environment) object.Object {
+	t.Helper()
+
+	l := lexer.NewLexer([]byte(input))
+	p := parser.NewParser()
+	program, err := p.Parse(l)
+	if err != nil {
+		t.Fatalf("%s: %s", input, err)
+	}
+
+	return Eval(program.(*ast.Program), env)
+}
+
 func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
 	result, ok := obj.(*object.Integer)
 	if !ok {
@@ -233,6 +255,31 @@ func TestForExpressions(t *testing.T) {
 	}
 }
 
+func TestForInExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`n = 0; for x in [1, 2, 3] { n = n + x }; n`, `6`},
+		{`s = ""; for c in "héllo" { s = c + s }; s`, `olléh`},
+		{`r = []; for k in {"b": 1, "a": 2} { r = push(r, k) }; r`, `["b", "a"]`},
+		{`r = []; for x in {3, 1, 2} { r = push(r, x) }; r`, `[3, 1, 2]`},
+		{`r = []; for x in (1, 2) { r = push(r, x * 2) }; r`, `[2, 4]`},
+		{`a = [1]; for x in a { if (x < 3) { a = push(a, x + 1) } }; a`, `[1, 2]`},
+		{`a = [1]; for x in a { if (x < 3) { a[0] = 5 } }; a`, `[5]`},
+		{`for x in [] { x }`, `null`},
+		{`f = fn() { for x in [1, 2, 3] { if (x == 2) { return x } } }; f()`, `2`},
+		{`for x in 5 { x }`, `TypeError: int is not iterable`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
 func testNullObject(t *testing.T, obj object.Object) bool {
 	if obj != NULL {
 		t.Errorf("object is not NULL. got=%T (%+v)", obj, obj)
@@ -473,6 +520,10 @@ myString[0] + myString[1] + myString[2]`,
 		},
 		{
 			`"foo"[-1]`,
+			"o",
+		},
+		{
+			`"foo"[-4]`,
 			"",
 		},
 	}
@@ -665,6 +716,10 @@ func TestArrayIndexExpressions(t *testing.T) {
 		},
 		{
 			"[1, 2, 3][-1]",
+			3,
+		},
+		{
+			"[1, 2, 3][-4]",
 			nil,
 		},
 	}
@@ -697,26 +752,34 @@ func TestHashLiterals(t *testing.T) {
 		t.Fatalf("Eval didn't return Hash. got=%T (%+v)", evaluated, evaluated)
 	}
 
-	expected := map[object.HashKey]int64{
-		(&object.String{Value: "one"}).HashKey():   1,
-		(&object.String{Value: "two"}).HashKey():   2,
-		(&object.String{Value: "three"}).HashKey(): 8,
-		(&object.Integer{Value: 4}).HashKey():      4,
-		TRUE.HashKey():                             5,
-		FALSE.HashKey():                            6,
+	expected := []struct {
+		key   object.Object
+		value int64
+	}{
+		{&object.String{Value: "one"}, 1},
+		{&object.String{Value: "two"}, 2},
+		{&object.String{Value: "three"}, 8},
+		{&object.Integer{Value: 4}, 4},
+		{TRUE, 5},
+		{FALSE, 6},
 	}
 
-	if len(result.Pairs) != len(expected) {
-		t.Fatalf("Hash has wrong num of pairs. got=%d", len(result.Pairs))
+	pairs := result.Pairs()
+	if len(pairs) != len(expected) {
+		t.Fatalf("Hash has wrong num of pairs. got=%d", len(pairs))
 	}
 
-	for expectedKey, expectedValue := range expected {
-		pair, ok := result.Pairs[expectedKey]
+	for i, tt := range expected {
+		if pairs[i].Key.Inspect() != tt.key.Inspect() {
+			t.Errorf("wrong key at position %d. got=%s, want=%s", i, pairs[i].Key.Inspect(), tt.key.Inspect())
+		}
+
+		value, ok := result.Get(tt.key)
 		if !ok {
 			t.Errorf("no pair for given key in Pairs")
 		}
 
-		testIntegerObject(t, pair.Value, expectedValue)
+		testIntegerObject(t, value, tt.value)
 	}
 }
 
@@ -728,88 +791,611 @@ func TestHashMerging(t *testing.T) {
 		t.Fatalf("Eval didn't return Hash. got=%T (%+v)", evaluated, evaluated)
 	}
 
-	expected := map[object.HashKey]int64{
-		(&object.String{Value: "a"}).HashKey(): 1,
-		(&object.String{Value: "b"}).HashKey(): 2,
+	expected := []struct {
+		key   string
+		value int64
+	}{
+		{"a", 1},
+		{"b", 2},
+	}
+
+	pairs := result.Pairs()
+	if len(pairs) != len(expected) {
+		t.Fatalf("Hash has wrong num of pairs. got=%d", len(pairs))
+	}
+
+	for i, tt := range expected {
+		if pairs[i].Key.String() != tt.key {
+			t.Errorf("wrong key at position %d. got=%s, want=%s", i, pairs[i].Key, tt.key)
+		}
+
+		testIntegerObject(t, pairs[i].Value, tt.value)
+	}
+}
+
+func TestHashOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"b": 1, "a": 2, 3: 4}`, `{"b": 1, "a": 2, 3: 4}`},
+		{`h = {"b": 1, "a": 2}; h["a"] = 5; h["c"] = 6; h`, `{"b": 1, "a": 5, "c": 6}`},
+		{`{"b": 1, "a": 2} + {"c": 3, "b": 0}`, `{"b": 0, "a": 2, "c": 3}`},
+		{`{"a": 1, "b": 2} == {"b": 2, "a": 1}`, `true`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.Inspect(), tt.expected)
+		}
+	}
+
+	hash := object.NewHash()
+	for i := int64(0); i < 10; i++ {
+		hash.Set(object.NewInteger(i), object.NewInteger(i))
+	}
+	for i := int64(0); i < 10; i += 2 {
+		if !hash.Delete(object.NewInteger(i)) {
+			t.Errorf("Delete(%d) = false", i)
+		}
+	}
+	hash.Set(object.NewInteger(0), object.NewInteger(0))
+	if hash.Inspect() != "{1: 1, 3: 3, 5: 5, 7: 7, 9: 9, 0: 0}" {
+		t.Errorf("wrong order after delete. got=%s", hash.Inspect())
+	}
+	if value, ok := hash.Get(object.NewInteger(7)); !ok || value.Inspect() != "7" {
+		t.Errorf("Get(7) = %v, %t", value, ok)
+	}
+}
+
+func TestHashBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`keys({"b": 1, "a": 2})`, `["b", "a"]`},
+		{`values({"b": 1, "a": 2})`, `[1, 2]`},
+		{`items({"b": 1, 2: "a"})`, `[["b", 1], [2, "a"]]`},
+		{`keys({,})`, `[]`},
+		{`h = {"a": 1, "b": 2}; [delete(h, "a"), delete(h, "a"), h]`, `[true, false, {"b": 2}]`},
+		{`[has({"a": 1}, "a"), has({"a": 1}, "b")]`, `[true, false]`},
+		{`[get({"a": 1}, "a"), get({"a": 1}, "b"), get({"a": 1}, "b", 0)]`, `[1, null, 0]`},
+		{`h = {"a": 1}; update(h, {"b": 2}, {"a": 3}); h`, `{"a": 3, "b": 2}`},
+		{`h = {"a": 1}; [merge(h, {"b": 2}), h]`, `[{"a": 1, "b": 2}, {"a": 1}]`},
+		{`h = {"a": 1, "b": 2}; [pop(h, "a"), h]`, `[1, {"b": 2}]`},
+		{`pop({"a": 1}, "b", 0)`, `0`},
+		{`pop({"a": 1}, "b")`, `KeyError: pop() key "b" not found`},
+		{`a = [1, 2]; [pop(a), a]`, `[2, [1]]`},
+		{`pop([1, 2], 0)`, `TypeError: pop() takes exactly 1 argument (2 given)`},
+		{`pop({"a": 1})`, `TypeError: pop() takes at least 2 arguments at most 3 (1 given)`},
+		{`h = {"a": 1}; [setdefault(h, "a", 2), setdefault(h, "b", 3), h]`, `[1, 3, {"a": 1, "b": 3}]`},
+		{`get({"a": 1}, [1])`, "TypeError: get() unusable as hash key: `array`"},
+		{`merge({"a": 1}, [1])`, "TypeError: merge() expected argument #2 to be `hash` got `array`"},
+		{`keys([1])`, "TypeError: keys() expected argument #1 to be `hash` got `array`"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestTuples(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`()`, `()`},
+		{`(1,)`, `(1,)`},
+		{`(1, "a", [2])`, `(1, "a", [2])`},
+		{`(1, 2)[1]`, `2`},
+		{`(1, 2)[2]`, `null`},
+		{`len((1, 2, 3))`, `3`},
+		{`(1, 2) + (3,)`, `(1, 2, 3)`},
+		{`[(1, 2) == (1, 2), (1, 2) == (1, 2.0), (1, 2) == [1, 2], (1, 2) < (1, 3), (1,) < (1, 0)]`, `[true, true, false, true, true]`},
+		{`typeof((1,))`, `tuple`},
+		{`tuple([1, 2])`, `(1, 2)`},
+		{`tuple()`, `()`},
+		{`t = (1, 2); t[0] = 3`, `TypeError: tuple does not support item assignment`},
+		{`h = {(0, 1): "a", (1, 0): "b"}; [h[(0, 1)], h[(1, 0)], h[(1, 1)]]`, `["a", "b", null]`},
+		{`h = {,}; h[(1, (2, "x"))] = 1; h[(1, (2, "x"))]`, `1`},
+		{`{([1],): 1}`, `unusable as hash key: tuple`},
+		{`h = {,}; h[[1]] = 2`, `unusable as hash key: array`},
+		{`hash((1, 2)) == hash((1.0, 2))`, `true`},
+		{`json_encode((1, [2]))`, `[1,[2]]`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestNumericHashKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`h = {1: "a"}; [h[1], h[1.0], get(h, 1.5)]`, `["a", "a", null]`},
+		{`h = {1: "a"}; h[1.0] = "b"; h`, `{1: "b"}`},
+		{`h = {0: "a"}; h[-0.0]`, `a`},
+		{`h = {2 ** 70: "a"}; h[2.0 ** 70]`, `a`},
+		{`h = {9007199254740993: "a"}; [h[9007199254740992.0], 9007199254740993 == 9007199254740992.0]`, `[null, false]`},
+		{`[9007199254740993 > 9007199254740992.0, 9007199254740992.0 < 9007199254740993, 9007199254740992 == 9007199254740992.0]`, `[true, true, true]`},
+		{`h = {nan: "a"}; h[nan]`, `a`},
+		{`hash(3) == hash(3.0)`, `true`},
+		{`len({1: 1, 1.0: 2, true: 3})`, `2`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
 	}
+}
+
+// collidingKey is a hash key whose HashKey is the same for every value.
+type collidingKey struct {
+	value *object.String
+}
+
+func (k collidingKey) String() string    { return k.value.String() }
+func (k collidingKey) Type() object.Type { return object.StringType }
+func (k collidingKey) Bool() bool        { return k.value.Bool() }
+func (k collidingKey) Inspect() string   { return k.value.Inspect() }
+func (k collidingKey) HashKey() object.HashKey {
+	return object.HashKey{Type: object.StringType, Value: 42}
+}
 
-	if len(result.Pairs) != len(expected) {
-		t.Fatalf("Hash has wrong num of pairs. got=%d", len(result.Pairs))
+func (k collidingKey) Compare(other object.Object) int {
+	if obj, ok := other.(collidingKey); ok {
+		return k.value.Compare(obj.value)
 	}
+	return 1
+}
 
-	for expectedKey, expectedValue := range expected {
-		pair, ok := result.Pairs[expectedKey]
+func TestHashCollisions(t *testing.T) {
+	a := collidingKey{&object.String{Value: "a"}}
+	b := collidingKey{&object.String{Value: "b"}}
+	c := collidingKey{&object.String{Value: "c"}}
+
+	hash := object.NewHash()
+	hash.Set(a, object.NewInteger(1))
+	hash.Set(b, object.NewInteger(2))
+	hash.Set(c, object.NewInteger(3))
+	hash.Set(collidingKey{&object.String{Value: "b"}}, object.NewInteger(4))
+
+	if hash.Len() != 3 {
+		t.Fatalf("wrong length. got=%d", hash.Len())
+	}
+	if !hash.Delete(a) || hash.Delete(a) {
+		t.Errorf("wrong result of Delete")
+	}
+	for key, expected := range map[collidingKey]int64{b: 4, c: 3} {
+		value, ok := hash.Get(key)
 		if !ok {
-			t.Errorf("no pair for given key in Pairs")
+			t.Errorf("no value for %s", key.Inspect())
+			continue
 		}
+		testIntegerObject(t, value, expected)
+	}
+	if _, ok := hash.Get(a); ok {
+		t.Errorf("deleted key %s still present", a.Inspect())
+	}
+}
+
+func TestSets(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{1, 2, 2, 1.0, 3}`, `{1, 2, 3}`},
+		{`{"a",}`, `{"a"}`},
+		{`set()`, `set()`},
+		{`set([3, 1, 3])`, `{3, 1}`},
+		{`set("abca")`, `{"a", "b", "c"}`},
+		{`set({"a": 1})`, `{"a"}`},
+		{`len({1, 2, 3})`, `3`},
+		{`typeof({1,})`, `set`},
+		{`{1, 2} | {2, 3}`, `{1, 2, 3}`},
+		{`{1, 2} & {2, 3}`, `{2}`},
+		{`{1, 2} - {2, 3}`, `{1}`},
+		{`{1, 2} ^ {2, 3}`, `{1, 3}`},
+		{`union({1,}, {2,}, {3,})`, `{1, 2, 3}`},
+		{`intersection({1, 2, 3}, {2, 3}, {3, 4})`, `{3}`},
+		{`difference({1, 2, 3}, {1,})`, `{2, 3}`},
+		{`symmetric_difference({1, 2}, {2, 3})`, `{1, 3}`},
+		{`[{1, 2} == {2, 1}, {1, 2} != {1, 3}, {1, 2} == [1, 2]]`, `[true, true, false]`},
+		{`[{1,} < {1, 2}, {1, 2} < {1, 2}, {1, 2} <= {1, 2}, {1, 3} <= {1, 2}]`, `[true, false, true, false]`},
+		{`[{1, 2} > {1,}, {1, 2} >= {2,}, {1,} >= {2,}]`, `[true, true, false]`},
+		{`s = {1,}; [add(s, 2), add(s, 2), s]`, `[true, false, {1, 2}]`},
+		{`s = {1, 2}; [discard(s, 1), s.discard(1), s]`, `[true, false, {2}]`},
+		{`s = {1, 2}; [s.remove(1), s.remove(1), s]`, `[true, false, {2}]`},
+		{`"file.txt".remove()`, `AttributeError: str has no member remove`},
+		{`remove({1,}, 1)`, "TypeError: remove() takes exactly 1 argument (2 given)"},
+		{`[has({1, (2, 3)}, (2, 3)), has({1,}, 2)]`, `[true, false]`},
+		{`s = {1,}; u = s | {2,}; [s, u]`, `[{1}, {1, 2}]`},
+		{`h = {,}; for id in [3, 1, 3, 2, 1] { h[id] = true }; set(keys(h)) == set([1, 2, 3])`, `true`},
+		{`json_encode({1, 2})`, `[1,2]`},
+		{`{[1], 2}`, `unusable as set element: array`},
+		{`add({1,}, [2])`, `unusable as set element: array`},
+		{`set([[1]])`, `unusable as set element: array`},
+		{`union({1,}, [2])`, "TypeError: union() expected argument #2 to be `set` got `array`"},
+		{`{1,} + {2,}`, `unknown operator: set + set`},
+	}
 
-		testIntegerObject(t, pair.Value, expectedValue)
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
 	}
 }
 
-func TestHashSelectorExpressions(t *testing.T) {
+func TestStructs(t *testing.T) {
+	point := `struct Point {
+	x;
+	y = 0;
+	fn norm2() { self.x * self.x + self.y * self.y };
+	fn move(dx, dy) { self.x = self.x + dx; self.y = self.y + dy; self }
+};
+`
+	counter := `struct Counter {
+	count;
+	step = 1;
+	items = [];
+	fn init(start) { self.count = start };
+	fn inc() { self.count = self.count + self.step; self.items = push(self.items, self.count) }
+};
+`
+
 	tests := []struct {
 		input    string
-		expected interface{}
+		expected string
 	}{
+		{point + `Point(3, 4)`, `Point{x: 3, y: 4}`},
+		{point + `Point(3)`, `Point{x: 3, y: 0}`},
+		{point + `Point(3, 4).norm2()`, `25`},
+		{point + `p = Point(3, 4); p.move(1, 1); [p.x, p.y]`, `[4, 5]`},
+		{point + `p = Point(3, 4); p.x = 10; p`, `Point{x: 10, y: 4}`},
+		{point + `p = Point(3, 4); m = p.norm2; p.x = 0; m()`, `16`},
+		{point + `Point(3, 4).norm2`, `<method Point.norm2>`},
+		{point + `[typeof(Point(1)), typeof(Point)]`, `["Point", "struct"]`},
+		{point + `Point`, `<struct Point>`},
+		{point + `[Point(1, 2) == Point(1, 2), Point(1, 2) == Point(2, 1)]`, `[true, false]`},
+		{point + `Point()`, `TypeError: Point() missing argument for field x`},
+		{point + `Point(1, 2, 3)`, `TypeError: Point() takes at most 2 arguments (3 given)`},
+		{point + `Point(1).z`, `AttributeError: Point has no member z`},
+		{point + `p = Point(1); p.z = 1`, `AttributeError: Point has no field z`},
+		{point + `p = Point(1); p.norm2 = 1`, `AttributeError: Point has no field norm2`},
+		{point + `Point(1).move(1)`, `TypeError: fn() takes exactly 2 argument (1 given)`},
+		{counter + `c = Counter(10); c.inc(); c.inc(); c`, `Counter{count: 12, step: 1, items: [11, 12]}`},
+		{counter + `a = Counter(0); a.inc(); b = Counter(0); b.items`, `[]`},
+		{`struct Empty {}; Empty()`, `Empty{}`},
+		{`struct Named { name; fn greet(greeting) { greeting + ", " + self.name } };
+		  re_replace("hi", "hi", fn(m) { Named("bob").greet(m.text) })`, `hi, bob`},
+		{`struct int { x }`, `TypeError: cannot declare struct with builtin type name int`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	nat := `nat = fn() { i = 0; for { yield i; i = i + 1 } }; `
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`gen = fn(n) { for i in range(n) { yield i * i } }; list(gen(4))`, `[0, 1, 4, 9]`},
+		{`gen = fn() { yield; yield 1 }; list(gen())`, `[null, 1]`},
+		{`gen = fn() { yield 1; return 5; yield 2 }; list(gen())`, `[1]`},
+		{`gen = fn() { yield 1 }; gen()`, `<generator>`},
+		{`gen = fn() { yield 1 }; typeof(gen())`, `iterator`},
+		{`gen = fn(n) { for i in range(n) { yield i } }; n = 0; for x in gen(4) { n = n + x }; n`, `6`},
+		{`gen = fn() { yield 1; yield 2 }; g = gen(); [list(g), list(g)]`, `[[1, 2], []]`},
+		{`n = 0; gen = fn() { n = n + 1; yield n }; g = gen(); n`, `0`},
+		{nat + `g = nat(); [next(g), next(g), g.next()]`, `[0, 1, 2]`},
+		{`gen = fn() { yield 1 }; g = gen(); [next(g), next(g, "done")]`, `[1, "done"]`},
+		{`gen = fn() { yield 1 }; g = gen(); next(g); next(g)`, `StopIteration: next() iterator is exhausted`},
+		{nat + `m = map(nat(), fn(x) { x * x }); [next(m), next(m), next(m)]`, `[0, 1, 4]`},
+		{nat + `f = filter(nat(), fn(x) { x % 3 == 0 }); [next(f), next(f)]`, `[0, 3]`},
+		{nat + `list(zip(nat(), "ab"))`, `[(0, "a"), (1, "b")]`},
+		{nat + `e = enumerate(map(nat(), str), 1); [next(e), next(e)]`, `[(1, "0"), (2, "1")]`},
+		{nat + `f = flat_map(nat(), fn(x) { [x, -x] }); [next(f), next(f), next(f)]`, `[0, 0, 1]`},
+		{nat + `any(map(nat(), fn(x) { x > 5 }))`, `true`},
+		{nat + `all(map(nat(), fn(x) { x < 5 }))`, `false`},
+		{`gen = fn() { yield 3; yield 1; yield 2 }; [sum(gen()), sorted(gen()), set(gen())]`, `[6, [1, 2, 3], {3, 1, 2}]`},
+		{`gen = fn() { yield 1; yield 2 }; reduce(gen(), fn(a, b) { a * 10 + b })`, `12`},
+		{`list(iter([1, 2]))`, `[1, 2]`},
+		{`i = iter("ab"); [next(i), next(i), next(i, null)]`, `["a", "b", null]`},
+		{`struct R { n; fn __iter__() { for i in range(self.n) { yield i } } }; list(R(3))`, `[0, 1, 2]`},
+		{nat + `g = nat(); next(g); close(g); list(g)`, `[]`},
+		{nat + `f = fn(g) { for x in g { if x == 2 { return x } } }; g = nat(); [f(g), list(g)]`, `[2, []]`},
+		{nat + `g = nat(); [any(map(g, fn(x) { x > 2 })), next(g, "closed")]`, `[true, "closed"]`},
+		{nat + `g = nat(); [list(zip(g, "ab")), next(g, "closed")]`, `[[(0, "a"), (1, "b")], "closed"]`},
+		{`gen = fn() { yield 1; yield 1 + "a"; yield 3 }; list(gen())`, `unknown operator: int + str`},
+		{`gen = fn() { yield 1 + "a" }; for x in gen() { x }`, `unknown operator: int + str`},
+		{`gen = fn() { yield 1 + "a" }; list(map(gen(), str))`, `unknown operator: int + str`},
+		{`gen = fn() { yield 1; yield 2 }; g = gen(); next(g); list(map(g, fn(x) { x + "a" }))`, `unknown operator: int + str`},
+		{`gen = fn() { yield 1 }; gen(1)`, `TypeError: fn() takes exactly 0 argument (1 given)`},
+		{`yield 1`, `SyntaxError: yield outside of a generator function`},
+		{`f = fn() { fn() { yield 1 } }; typeof(f())`, `fn`},
+		{`next([1])`, "TypeError: next() expected argument #1 to be `iterator` got `array`"},
+		{`list(1)`, "TypeError: list() expected an iterable got `int`"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestGeneratorGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	env := object.NewEnvironment()
+	testEvalEnv(t, `nat = fn() { i = 0; for { yield i; i = i + 1 } }`, env)
+	for i := 0; i < 10; i++ {
+		// consumed to the end, closed, never started and abandoned
+		testEvalEnv(t, `gen = fn() { yield 1; yield 2 }; list(gen())`, env)
+		testEvalEnv(t, `g = nat(); next(g); close(g)`, env)
+		testEvalEnv(t, `nat()`, env)
+		testEvalEnv(t, `any(map(nat(), fn(x) { x > 2 }))`, env)
+		testEvalEnv(t, `next(nat())`, env)
+	}
+	testEvalEnv(t, `g = null`, env)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("generators leaked %d goroutines", n-before)
+	}
+}
+
+func TestGeneratorsClosedByConsumers(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	// The generators stay referenced, so their goroutines only exit if
+	// the consumers close them rather than the garbage collector.
+	env := object.NewEnvironment()
+	testEvalEnv(t, `
+		nat = fn() { i = 0; for { yield i; i = i + 1 } };
+		find = fn(g, n) { for x in g { if x == n { return x } } };
+		gens = map(range(6), fn(i) { nat() });
+		map(gens, next)
+	`, env)
+	for _, input := range []string{
+		`find(gens[0], 3)`,
+		`for x in gens[1] { x + "a" }`,
+		`all(map(gens[2], fn(x) { x < 3 }))`,
+		`list(zip("abc", gens[3]))`,
+		`list(map(gens[4], fn(x) { if x == 3 { x + "a" } else { x } }))`,
+		`[x, y] = gens[5]`,
+	} {
+		testEvalEnv(t, input, env)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("generators left %d goroutines running", n-before)
+	}
+	if _, ok := env.Get("gens"); !ok {
+		t.Fatal("gens is not defined")
+	}
+}
+
+func TestTasks(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`wait(spawn(fn(a, b) { a + b }, 1, 2))`, `3`},
+		{`t = spawn(fn() { 1 }); [t.wait(), wait(t)]`, `[1, 1]`},
+		{`wait(spawn(fn() { 1 }), spawn(fn() { 2 }))`, `[1, 2]`},
+		{`wait(map(range(5), fn(i) { spawn(fn() { time.sleep(5 - i); i * i }) }))`, `[0, 1, 4, 9, 16]`},
+		{`wait(spawn(len, "abc"))`, `3`},
+		{`t = spawn(fn() { 1 }); [str(t), wait(t), str(t)]`, `["<task running>", 1, "<task done>"]`},
+		{`wait(spawn(fn() { 1 + "a" }))`, `unknown operator: int + str`},
+		{`wait(1)`, "TypeError: wait() expected a `task` got `int`"},
+		{`spawn(1)`, "TypeError: spawn() expected argument #1 to be `fn` got `int`"},
+
+		{`ch = channel(); spawn(fn() { send(ch, 1) }); recv(ch)`, `1`},
+		{`ch = channel(2); send(ch, 1); ch.send(2); [str(ch), len(ch), recv(ch), ch.recv()]`, `["<channel 2/2>", 2, 1, 2]`},
+		{`ch = channel(); spawn(fn() { for i in range(3) { send(ch, i) }; close(ch) }); list(ch)`, `[0, 1, 2]`},
+		{`ch = channel(1); send(ch, 1); close(ch); [recv(ch), recv(ch)]`, `[1, null]`},
+		{`ch = channel(); close(ch); send(ch, 1)`, `ValueError: send() on closed channel`},
+		{`ch = channel(); close(ch); close(ch)`, `ValueError: close() of closed channel`},
+		{`channel(-1)`, `ValueError: channel() size must be between 0 and 1048576`},
+		{`recv(1)`, "TypeError: recv() expected argument #1 to be `channel` got `int`"},
+
+		{`a = channel(1); b = channel(1); send(b, "b"); select([a, b])`, `(1, "b")`},
+		{`a = channel(1); b = channel(1); select([a, (b, 2)]); recv(b)`, `2`},
+		{`a = channel(); spawn(fn() { send(a, 1) }); select([channel(), a])`, `(1, 1)`},
+		{`a = channel(); close(a); select([a])`, `(0, null)`},
+		{`select([channel()], false)`, `(-1, null)`},
+		{`select([])`, `ValueError: select() would block forever without operations`},
+		{`select([1])`, "TypeError: select() expected a `channel` or `tuple` got `int`"},
+		{`select([(1, 2)])`, `TypeError: select() expected a send to be a (channel, value) tuple`},
+		{`a = channel(); close(a); select([(a, 1)])`, `ValueError: select() send on closed channel`},
+
+		{`m = mutex(); lock(m); [str(m), unlock(m), str(m)]`, `["<mutex locked>", null, "<mutex>"]`},
+		{`m = mutex(); m.lock(); m.unlock(); m.unlock()`, `ValueError: unlock() of unlocked mutex`},
 		{
-			`{"foo": 5}.foo`,
-			5,
-		},
-		{
-			`{"foo": 5}.bar`,
-			nil,
+			`m = mutex(); h = {"n": 0};
+			inc = fn() { for i in range(50) { lock(m); n = h.n; time.sleep(0); h.n = n + 1; unlock(m) } };
+			wait(map(range(4), fn(i) { spawn(inc) })); h.n`,
+			`200`,
 		},
 		{
-			`{,}.foo`,
-			nil,
+			`results = []; ch = channel();
+			worker = fn(id) { for x in ch { results = push(results, x) }; id };
+			ws = map(range(3), fn(i) { spawn(worker, i) });
+			for i in range(6) { send(ch, i) }; close(ch); wait(ws)`,
+			`[0, 1, 2]`,
 		},
 	}
 
 	for _, tt := range tests {
 		evaluated := testEval(tt.input)
-		integer, ok := tt.expected.(int)
-		if ok {
-			testIntegerObject(t, evaluated, int64(integer))
-		} else {
-			testNullObject(t, evaluated)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
 		}
 	}
 }
 
-func TestHashIndexExpressions(t *testing.T) {
+func TestTasksShareObjects(t *testing.T) {
+	env := object.NewEnvironment()
+	evaluated := testEvalEnv(t, `
+		shared = {"hits": [], "set": set()};
+		work = fn(i) {
+			for j in range(20) {
+				shared.hits = push(shared.hits, i);
+				add(shared.set, j);
+				time.sleep(0)
+			};
+			i
+		};
+		tasks = map(range(8), fn(i) { spawn(work, i) });
+		[wait(tasks), len(shared.hits), len(shared.set)]
+	`, env)
+
+	expected := `[[0, 1, 2, 3, 4, 5, 6, 7], 160, 20]`
+	if evaluated.String() != expected {
+		t.Errorf("wrong result. got=%s, want=%s", evaluated.String(), expected)
+	}
+}
+
+func TestTasksKeepTheirFrames(t *testing.T) {
+	depth := MaxDepth
+	MaxDepth = 40
+	defer func() { MaxDepth = depth }()
+
+	// a blocks in recv, then b blocks in recv deep down its call stack.
+	// Resumed, a compares instances and calls __eq__ from its own frame
+	// rather than b's. Then b raises an error in __eq__, which must reach
+	// b's own comparison.
+	input := `
+		down = fn(n) { if n == 0 { true } else { down(n - 1) } };
+		struct S { x; fn __eq__(o) { if o.x == 0 { 1 + "a" } else { down(10) } } };
+		a = channel(); b = channel(); ready = channel();
+		deep = fn(n) { if n == 0 { send(ready, 1); recv(b); [S(1)] == [S(0)] } else { deep(n - 1) } };
+		ta = spawn(fn() { send(ready, 1); recv(a); [S(1)] == [S(2)] });
+		recv(ready);
+		tb = spawn(deep, 30);
+		recv(ready);
+		send(a, 1);
+		result = wait(ta);
+		send(b, 1);
+		[result, wait(tb)]
+	`
+
+	// wait raises the error of b again.
+	evaluated := testEvalEnv(t, input, object.NewEnvironment())
+	expected := `unknown operator: int + str`
+	if evaluated.String() != expected {
+		t.Errorf("wrong result. got=%s, want=%s", evaluated.String(), expected)
+	}
+}
+
+func TestProtocolMethods(t *testing.T) {
+	vector := `struct Vec {
+	x;
+	y;
+	fn __add__(other) { Vec(self.x + other.x, self.y + other.y) };
+	fn __mul__(k) { Vec(self.x * k, self.y * k) };
+	fn __rmul__(k) { self * k };
+	fn __neg__() { Vec(-self.x, -self.y) };
+	fn __eq__(other) { self.x == other.x && self.y == other.y };
+	fn __lt__(other) { self.x * self.x + self.y * self.y < other.x * other.x + other.y * other.y };
+	fn __str__() { "<" + str(self.x) + ", " + str(self.y) + ">" };
+	fn __len__() { 2 };
+	fn __getitem__(i) { if (i == 0) { self.x } else { self.y } };
+	fn __setitem__(i, v) { if (i == 0) { self.x = v } else { self.y = v } };
+	fn __iter__() { [self.x, self.y] };
+	fn __call__(k) { self.x * k + self.y }
+};
+`
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{vector + `Vec(1, 2) + Vec(3, 4)`, `<4, 6>`},
+		{vector + `Vec(1, 2) * 3`, `<3, 6>`},
+		{vector + `3 * Vec(1, 2)`, `<3, 6>`},
+		{vector + `-Vec(1, 2)`, `<-1, -2>`},
+		{vector + `[Vec(1, 2) == Vec(1, 2), Vec(1, 2) != Vec(1, 2), Vec(1, 2) == Vec(2, 1)]`, `[true, false, false]`},
+		{vector + `[Vec(1, 0) < Vec(2, 0), Vec(1, 0) > Vec(2, 0), Vec(1, 0) <= Vec(1, 0), Vec(1, 0) >= Vec(2, 0)]`, `[true, false, true, false]`},
+		{vector + `str(Vec(1, 2))`, `<1, 2>`},
+		{vector + `[Vec(1, 2), Vec(3, 4)]`, `[<1, 2>, <3, 4>]`},
+		{vector + `len(Vec(1, 2))`, `2`},
+		{vector + `v = Vec(1, 2); [v[0], v[1]]`, `[1, 2]`},
+		{vector + `v = Vec(1, 2); v[1] = 5; v`, `<1, 5>`},
+		{vector + `n = 0; for c in Vec(3, 4) { n = n + c }; n`, `7`},
+		{vector + `set(Vec(3, 3))`, `{3}`},
+		{vector + `Vec(2, 1)(10)`, `21`},
+		{vector + `re_replace("a", "a", fn(m) { str(Vec(1, 2)) })`, `<1, 2>`},
+		{vector + `sorted([Vec(3, 0), Vec(1, 0), Vec(2, 0)])`, `[<1, 0>, <2, 0>, <3, 0>]`},
+		{vector + `Vec(1, 2) - Vec(1, 2)`, `unknown operator: Vec - Vec`},
+		{`struct P { x }; P(1) + 1`, `unknown operator: P + int`},
+		{`struct P { x }; P(1)[0]`, `index operator not supported: P`},
+		{`struct P { x }; p = P(1); p[0] = 1`, `TypeError: P does not support item assignment`},
+		{`struct P { x }; P(1)()`, `not a function: P`},
+		{`struct P { x }; len(P(1))`, `TypeError: object of type 'P' has no len()`},
+		{`struct P { x; fn __len__() { "a" } }; len(P(1))`, "TypeError: len() __len__ returned `str` instead of `int`"},
+		{`struct P { x; fn __str__() { 1 } }; str(P(1))`, "TypeError: str() __str__ returned `int` instead of `str`"},
+		{`struct P { x; fn __add__(o) { o + true } }; P(1) + 1`, `unknown operator: int + bool`},
+		{`struct A { x; fn __eq__(o) { [self] == [o] } }; A(1) == A(1)`, `RecursionError: maximum recursion depth exceeded`},
+		{`struct A { x; fn __eq__(o) { undefined_x } }; [A(1)] == [A(1)]`, `identifier not found: undefined_x`},
+		{`struct A { x; fn __lt__(o) { undefined_x } }; sorted([A(2), A(1)])`, `identifier not found: undefined_x`},
+		{`struct A { x; fn __str__() { undefined_x } }; str([A(1)])`, `identifier not found: undefined_x`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", strings.TrimPrefix(tt.input, vector), evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestHashSelectorExpressions(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected interface{}
 	}{
 		{
-			`{"foo": 5}["foo"]`,
+			`{"foo": 5}.foo`,
 			5,
 		},
 		{
-			`{"foo": 5}["bar"]`,
+			`{"foo": 5}.bar`,
 			nil,
 		},
 		{
-			`key = "foo"; {"foo": 5}[key]`,
-			5,
-		},
-		{
-			`{,}["foo"]`,
+			`{,}.foo`,
 			nil,
 		},
-		{
-			`{5: 5}[5]`,
-			5,
-		},
-		{
-			`{true: 5}[true]`,
-			5,
-		},
-		{
-			`{false: 5}[false]`,
-			5,
-		},
 	}
 
 	for _, tt := range tests {
@@ -822,3 +1408,1182 @@ func TestHashIndexExpressions(t *testing.T) {
 		}
 	}
 }
+
+func TestMethodCalls(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"a,b,c".split(",")`, `["a", "b", "c"]`},
+		{`" a b ".upper().split(" ").join("-")`, `-A-B-`},
+		{`"foobar".find("bar")`, `3`},
+		{`s = "éb"; i = s.find("b"); [i, s[i], len(s)]`, `[1, "b", 2]`},
+		{`"日本語".find("語")`, `2`},
+		{`"42".int() + 1`, `43`},
+		{`[3, 1, 2].sorted().reversed()`, `[3, 2, 1]`},
+		{`a = [1]; a.push(2)`, `[1, 2]`},
+		{`[1, 2, 3].len()`, `3`},
+		{`(1, 2).len()`, `2`},
+		{`{"a": 1, "b": 2}.keys()`, `["a", "b"]`},
+		{`h = {"a": 1}; h.update({"b": 2}); h.items()`, `[["a", 1], ["b", 2]]`},
+		{`{"keys": 1}.keys`, `1`},
+		{`{"a": 1}.missing`, `null`},
+		{`{1, 2}.union({3,})`, `{1, 2, 3}`},
+		{`x = -255; x.abs().hex()`, `0xff`},
+		{`f = 2.5; f.int()`, `2`},
+		{`"abc".upper`, `<built-in method upper of str>`},
+		{`up = "abc".upper; up()`, `ABC`},
+		{`"abc".foo()`, `AttributeError: str has no member foo`},
+		{`true.foo`, `bool does not support selection`},
+		{`"a".split(1)`, "TypeError: split() expected argument #2 to be `str` got `int`"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestHigherOrderBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`map([1, 2, 3], fn(x) { x * 2 })`, `[2, 4, 6]`},
+		{`map("ab", upper)`, `["A", "B"]`},
+		{`[1, 2].map(fn(x) { x + 1 })`, `[2, 3]`},
+		{`map([], fn(x) { x })`, `[]`},
+		{`filter(range(10), fn(x) { x % 3 == 0 })`, `[0, 3, 6, 9]`},
+		{`reduce([1, 2, 3, 4], fn(acc, x) { acc * x })`, `24`},
+		{`reduce([], fn(acc, x) { acc + x }, 10)`, `10`},
+		{`reduce(["a", "b"], fn(acc, x) { acc + x }, "")`, `ab`},
+		{`[any([0, "", 1]), any([]), all([1, "a"]), all([1, 0]), all([])]`, `[true, false, true, false, true]`},
+		{`sum([1, 2, 3])`, `6`},
+		{`sum([0.5, 1])`, `1.5`},
+		{`sum([[1], [2]], [])`, `[1, 2]`},
+		{`sum(range(101))`, `5050`},
+		{`sum([9223372036854775807, 1])`, `9223372036854775808`},
+		{`range(5)`, `range(0, 5)`},
+		{`range(10, 0, -3)`, `range(10, 0, -3)`},
+		{`map(range(10, 0, -3), fn(x) { x })`, `[10, 7, 4, 1]`},
+		{`[len(range(0)), len(range(5, 1)), len(range(1, 10, 2))]`, `[0, 0, 5]`},
+		{`n = 0; for i in range(1, 4) { n = n + i }; n`, `6`},
+		{`len(range(-9223372036854775807 - 1, 9223372036854775807))`, `9223372036854775807`},
+		{`map(range(9223372036854775806, 9223372036854775807 - 3, -2), fn(x) { x })`, `[9223372036854775806]`},
+		{`zip([1, 2, 3], "ab")`, `[(1, "a"), (2, "b")]`},
+		{`zip()`, `[]`},
+		{`enumerate(["a", "b"])`, `[(0, "a"), (1, "b")]`},
+		{`enumerate("ab", 1)`, `[(1, "a"), (2, "b")]`},
+		{`flat_map([1, 2], fn(x) { [x, x * 10] })`, `[1, 10, 2, 20]`},
+		{`group_by(range(6), fn(x) { x % 3 })`, `{0: [0, 3], 1: [1, 4], 2: [2, 5]}`},
+		{`group_by(["ab", "c", "de"], len)`, `{2: ["ab", "de"], 1: ["c"]}`},
+		{`sorted([3, 1, 2])`, `[1, 2, 3]`},
+		{`sorted({3, 1, 2}, null, true)`, `[3, 2, 1]`},
+		{`sorted(["bb", "a", "ccc", "dd"], len)`, `["a", "bb", "dd", "ccc"]`},
+		{`sorted(["bb", "a", "ccc", "dd"], len, true)`, `["ccc", "bb", "dd", "a"]`},
+		{`sorted([(1, "b"), (0, "c"), (1, "a")], fn(p) { p[0] })`, `[(0, "c"), (1, "b"), (1, "a")]`},
+		{`map(1, fn(x) { x })`, "TypeError: map() expected an iterable got `int`"},
+		{`map([1], 1)`, "TypeError: map() expected argument #2 to be `fn` got `int`"},
+		{`map([1, 2], fn(x) { x + "a" })`, `unknown operator: int + str`},
+		{`reduce([], fn(acc, x) { acc })`, `TypeError: reduce() of empty iterable with no initial value`},
+		{`sum(["a"])`, `unknown operator: int + str`},
+		{`range(1, 2, 0)`, `ValueError: range() step must not be zero`},
+		{`range(1.5)`, "TypeError: range() expected argument #1 to be `int` got `float`"},
+		{`range(9223372036854775808)`, `OverflowError: range() argument #1 is too large`},
+		{`enumerate([], "a")`, "TypeError: enumerate() expected argument #2 to be `int` got `str`"},
+		{`flat_map([1], fn(x) { x })`, "TypeError: flat_map() expected an iterable got `int`"},
+		{`group_by([1], fn(x) { [x] })`, "TypeError: group_by() unusable as hash key: `array`"},
+		{`sorted([1], 1)`, "TypeError: sorted() expected argument #2 to be `fn` got `int`"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`[1, 2, 3, 4, 5][1:3]`, `[2, 3]`},
+		{`[1, 2, 3, 4, 5][:2]`, `[1, 2]`},
+		{`[1, 2, 3, 4, 5][3:]`, `[4, 5]`},
+		{`[1, 2, 3, 4, 5][:]`, `[1, 2, 3, 4, 5]`},
+		{`[1, 2, 3, 4, 5][::2]`, `[1, 3, 5]`},
+		{`[1, 2, 3, 4, 5][::-1]`, `[5, 4, 3, 2, 1]`},
+		{`[1, 2, 3, 4, 5][-2:]`, `[4, 5]`},
+		{`[1, 2, 3, 4, 5][:-2]`, `[1, 2, 3]`},
+		{`[1, 2, 3, 4, 5][3:0:-1]`, `[4, 3, 2]`},
+		{`[1, 2, 3, 4, 5][-10:10]`, `[1, 2, 3, 4, 5]`},
+		{`[1, 2, 3, 4, 5][4:1]`, `[]`},
+		{`[1, 2, 3][null:null:-2]`, `[3, 1]`},
+		{`[1, 2, 3][:99999999999999999999]`, `[1, 2, 3]`},
+		{`[1, 2, 3][::-99999999999999999999]`, `[3]`},
+		{`a = [1, 2, 3]; b = a[:]; b[0] = 9; a`, `[1, 2, 3]`},
+		{`(1, 2, 3)[1:]`, `(2, 3)`},
+		{`(1, 2, 3)[-1]`, `3`},
+		{`"hello"[1:4]`, `ell`},
+		{`"hello"[::-1]`, `olleh`},
+		{`"héllo"[1:3]`, `él`},
+		{`"héllo"[-4]`, `é`},
+		{`a = [1, 2, 3]; a[-1] = 4; a`, `[1, 2, 4]`},
+		{`a = [1, 2, 3, 4]; a[1:3] = ["x"]; a`, `[1, "x", 4]`},
+		{`a = [1, 2]; a[1:1] = (7, 8); a`, `[1, 7, 8, 2]`},
+		{`a = [1, 2, 3]; a[:] = []; a`, `[]`},
+		{`a = [1, 2, 3]; a[len(a):] = a; a`, `[1, 2, 3, 1, 2, 3]`},
+		{`a = [1, 2, 3, 4]; a[::2] = "xy"; a`, `["x", 2, "y", 4]`},
+		{`a = [1, 2, 3]; a[::-1] = range(3); a`, `[2, 1, 0]`},
+		{`a = [1, 2, 3]; a[-4] = 0`, `IndexError: array[3] index out of range: -4`},
+		{`a = [1, 2, 3, 4]; a[::2] = [1]`, `ValueError: attempt to assign sequence of size 1 to extended slice of size 2`},
+		{`a = [1]; a[:] = 1`, `TypeError: int is not iterable`},
+		{`t = (1, 2); t[:1] = [3]`, `TypeError: tuple does not support slice assignment`},
+		{`[1, 2][::0]`, `ValueError: slice step cannot be zero`},
+		{`[1, 2]["a":]`, "TypeError: slice indices must be integers or null got `str`"},
+		{`{"a": 1}[1:]`, `slice operator not supported: hash`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestStrictIndex(t *testing.T) {
+	defer func(strict bool) { object.StrictIndex = strict }(object.StrictIndex)
+	object.StrictIndex = true
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`[1, 2, 3][-1]`, `3`},
+		{`[1, 2, 3][3]`, `IndexError: array index out of range`},
+		{`(1, 2)[-3]`, `IndexError: tuple index out of range`},
+		{`"abc"[5]`, `IndexError: str index out of range`},
+		{`[1, 2, 3][5:]`, `[]`},
+		{`{"a": 1}["b"]`, `null`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestDestructuring(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`a, b = 1, 2; a, b = b, a; [a, b]`, `[2, 1]`},
+		{`a, b = (1, 2); [a, b]`, `[1, 2]`},
+		{`a, b, c = "xyz"; [a, b, c]`, `["x", "y", "z"]`},
+		{`a, b = 1, 2`, `null`},
+		{`a = [0, 0]; h = {,}; a[1], h.k = 1, 2; [a, h]`, `[[0, 1], {"k": 2}]`},
+		{`a = [1, 2, 3]; a[0], a[2] = a[2], a[0]; a`, `[3, 2, 1]`},
+		{`(a, b) = [1, 2]; a + b`, `3`},
+		{`[head, ...tail] = [1, 2, 3]; [head, tail]`, `[1, [2, 3]]`},
+		{`[...init, last] = range(4); [init, last]`, `[[0, 1, 2], 3]`},
+		{`[a, ...rest, z] = [1, 2]; [a, rest, z]`, `[1, [], 2]`},
+		{`[a, [b, c]] = [1, (2, 3)]; [a, b, c]`, `[1, 2, 3]`},
+		{`g = fn() { for { yield 1 } }; a, b = g()`, `ValueError: too many values to unpack (expected 2)`},
+		{`p = {"name": "Ann", "age": 30}; {name, age} = p; [name, age]`, `["Ann", 30]`},
+		{`{name, keys} = {"name": "Ann"}; [name, keys]`, `["Ann", null]`},
+		{`{name} = {"name": "Ann"}; name`, `Ann`},
+		{`f = fn({z}) { z }; f({"z": 5})`, `5`},
+		{`r = []; for {n} in [{"n": 1}, {"n": 2}] { r = r + [n] }; r`, `[1, 2]`},
+		{`{"a": x, 1: [y, z]} = {"a": 0, 1: (2, 3)}; [x, y, z]`, `[0, 2, 3]`},
+		{`struct P { x; y }; {x, y} = P(1, 2); x + y`, `3`},
+		{`[1, ...[2, 3], ...range(4, 6), 6]`, `[1, 2, 3, 4, 5, 6]`},
+		{`f = fn([a, b], {k,}) { a + b + k }; f((1, 2), {"k": 3})`, `6`},
+		{`struct S { fn m([a, ...b]) { b } }; S().m("abc")`, `["b", "c"]`},
+		{`r = []; for k, v in {"a": 1, "b": 2}.items() { r = r + [k + str(v)] }; r`, `["a1", "b2"]`},
+		{`r = 0; for [a, [b, c]] in [[1, [2, 3]], [4, [5, 6]]] { r = r + a * b * c }; r`, `126`},
+		{`r = []; for i, (k, v) in enumerate([("a", 1)]) { r = [i, k, v] }; r`, `[0, "a", 1]`},
+		{`a, b = 1`, `TypeError: int is not iterable`},
+		{`a, b = [1, 2, 3]`, `ValueError: too many values to unpack (expected 2)`},
+		{`a, b, c = [1, 2]`, `ValueError: not enough values to unpack (expected 3, got 2)`},
+		{`[a, ...b, c] = [1]`, `ValueError: not enough values to unpack (expected at least 2, got 1)`},
+		{`f = fn([a, b]) { a }; f(1)`, `TypeError: int is not iterable`},
+		{`for a, b in [1] {}`, `TypeError: int is not iterable`},
+		{`{a,} = 1`, `AttributeError: int has no member a`},
+		{`[...1]`, `TypeError: int is not iterable`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestCompoundAssignment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`i = 1; i += 2`, `3`},
+		{`i = 10; i -= 4; i`, `6`},
+		{`i = 3; i *= 4; i /= 2; i`, `6`},
+		{`i = 7; i //= 2; i`, `3`},
+		{`i = 7; i %= 4; i`, `3`},
+		{`i = 2; i **= 10; i`, `1024`},
+		{`i = 12; i |= 3; i &= 6; i ^= 1; i`, `7`},
+		{`i = 1; i <<= 4; i >>= 2; i`, `4`},
+		{`s = "a"; s += "b"; s`, `ab`},
+		{`a = [1]; a += [2]; a`, `[1, 2]`},
+		{`a = [1, 2]; a[1] *= 5; a`, `[1, 10]`},
+		{`a = [1, 2]; a[-1] += 1`, `null`},
+		{`h = {"n": 1}; h.n += 1; h["n"] += 1; h`, `{"n": 3}`},
+		{`struct C { n = 0 }; c = C(); c.n += 2; c.n`, `2`},
+		{`struct V { x; fn __add__(o) { V(self.x + o) } }; v = V(1); v += 2; v.x`, `3`},
+		{`calls = {"n": 0}; f = fn() { calls.n = calls.n + 1; 0 }; a = [5]; a[f()] += 1; [a, calls.n]`, `[[6], 1]`},
+		{`calls = {"n": 0}; f = fn() { calls.n = calls.n + 1; {"k": 1} }; f().k += 1; calls.n`, `1`},
+		{`x = null; x ??= 1; x ??= 2; x`, `1`},
+		{`h = {,}; h.a ??= []; h["a"] ??= [1]; h`, `{"a": []}`},
+		{`calls = {"n": 0}; x = 0; x ??= calls.n = 1; [x, calls.n]`, `[0, 0]`},
+		{`i = 0; i++; i++; i--; i`, `1`},
+		{`a = [0]; a[0]++; a[0]++; a`, `[2]`},
+		{`h = {"n": 1.5}; h.n--; h.n`, `0.5`},
+		{`i = "a"; i++`, `unknown operator: str + int`},
+		{`x += 1`, `identifier not found: x`},
+		{`t = (1,); t[0] += 1`, `TypeError: tuple does not support item assignment`},
+		{`i = 1; i /= 0`, `division by zero: 1 / 0`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestLogicalOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`1 && 2`, `2`},
+		{`0 || "none"`, `none`},
+		{`[] && undefined`, `[]`},
+		{`"a" || undefined`, `a`},
+		{`null || [1]`, `[1]`},
+		{`x = null; x != null && x.a`, `false`},
+		{`x = {"a": 1}; x != null && x.a`, `1`},
+		{`calls = {"n": 0}; f = fn() { calls.n = calls.n + 1; true }; true || f(); false && f(); calls.n`, `0`},
+		{`!null`, `true`},
+		{`![]`, `true`},
+		{`!"a"`, `false`},
+		{`!!{1,}`, `true`},
+		{`null ?? 1`, `1`},
+		{`0 ?? 1`, `0`},
+		{`false ?? 1`, `false`},
+		{`null ?? null ?? 3`, `3`},
+		{`1 ?? undefined`, `1`},
+		{`null ?? 1 == 1`, `true`},
+		{`x = null; x ?? 0 || 5`, `5`},
+		{`true && undefined`, `identifier not found: undefined`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestOptionalChaining(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`x = null; x?.a`, `null`},
+		{`x = null; x?.a.b.c()`, `null`},
+		{`x = null; x?.a[0][1:]`, `null`},
+		{`x = {"a": {"b": 2}}; x?.a?.b`, `2`},
+		{`x = {"a": null}; x.a?.b`, `null`},
+		{`x = {,}; x?.missing`, `null`},
+		{`"abc"?.upper()`, `ABC`},
+		{`x = null; x?.a ?? "default"`, `default`},
+		{`calls = {"n": 0}; f = fn() { calls.n = calls.n + 1 }; x = null; x?.a(f()); calls.n`, `0`},
+		{`x = {"a": null}; x?.a.b`, `null does not support selection`},
+		{`x = null; x.a`, `null does not support selection`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("wrong result for %q. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestHashIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{
+			`{"foo": 5}["foo"]`,
+			5,
+		},
+		{
+			`{"foo": 5}["bar"]`,
+			nil,
+		},
+		{
+			`key = "foo"; {"foo": 5}[key]`,
+			5,
+		},
+		{
+			`{,}["foo"]`,
+			nil,
+		},
+		{
+			`{5: 5}[5]`,
+			5,
+		},
+		{
+			`{true: 5}[true]`,
+			5,
+		},
+		{
+			`{false: 5}[false]`,
+			5,
+		},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		integer, ok := tt.expected.(int)
+		if ok {
+			testIntegerObject(t, evaluated, int64(integer))
+		} else {
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestBigIntegers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"-9223372036854775807 - 2", "-9223372036854775809"},
+		{"4294967296 * 4294967296", "18446744073709551616"},
+		{"x = -9223372036854775807 - 1; -x", "9223372036854775808"},
+		{"1 << 100", "1267650600228229401496703205376"},
+		{"(1 << 100) >> 99", "2"},
+		{"(1 << 100) - (1 << 100) + 5", "5"},
+		{"(1 << 64) | 1", "18446744073709551617"},
+		{"(1 << 64) & 3", "0"},
+		{"x = 1 << 64; ~x", "-18446744073709551617"},
+		{"(1 << 64) % 7", "2"},
+		{"(1 << 64) / (1 << 63)", "2"},
+		{"99999999999999999999", "99999999999999999999"},
+		{"fact = fn(n) { if n == 0 { return 1 }; n * fact(n - 1) }; fact(25)", "15511210043330985984000000"},
+		{"pow(2, 100)", "1267650600228229401496703205376"},
+		{"pow(-3, 41)", "-36472996377170786403"},
+		{"abs(0 - (1 << 70))", "1180591620717411303424"},
+		{`str(1 << 70)`, "1180591620717411303424"},
+		{`hex(1 << 64)`, "0x10000000000000000"},
+		{`hex(-255)`, "-0xff"},
+		{`bin(1 << 65)`, "0b100000000000000000000000000000000000000000000000000000000000000000"},
+		{`oct(1 << 66)`, "010000000000000000000000"},
+		{`int("123456789012345678901234567890")`, "123456789012345678901234567890"},
+		{`int(1e20)`, "100000000000000000000"},
+		{`divmod(1 << 70, 1000)`, "[1180591620717411303, 424]"},
+		{`{1 << 70: "big"}[1 << 70]`, "big"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestBigIntegerDemotion(t *testing.T) {
+	tests := []string{
+		"(1 << 100) >> 100",
+		"(1 << 64) - (1 << 64)",
+		"9223372036854775807 + 1 - 1",
+		"int(\"42\")",
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt)
+		integer, ok := evaluated.(*object.Integer)
+		if !ok {
+			t.Fatalf("object is not Integer. got=%T (%+v)", evaluated, evaluated)
+		}
+		if integer.IsBig() {
+			t.Errorf("%s: expected a demoted integer, got big %s", tt, integer.Inspect())
+		}
+	}
+}
+
+func TestBigIntegerComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"(1 << 64) == (1 << 64)", true},
+		{"(1 << 64) > 9223372036854775807", true},
+		{"0 - (1 << 64) < -9223372036854775807", true},
+		{"(1 << 64) == 18446744073709551616.0", true},
+		{"(1 << 64) < 1e30", true},
+		{"hash(1 << 64) == hash((1 << 65) >> 1)", true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestRuntimeErrorGuards(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"5 % 0", "division by zero: 5 % 0"},
+		{"(1 << 64) % 0", "division by zero: 18446744073709551616 % 0"},
+		{"1 << -1", "ValueError: negative shift count: -1"},
+		{"1 >> -1", "ValueError: negative shift count: -1"},
+		{"(1 << 64) << -1", "ValueError: negative shift count: -1"},
+		{"fn() {} < fn() {}", "TypeError: < not supported between fn and fn"},
+		{"len < len", "TypeError: < not supported between builtin and builtin"},
+		{"f = fn(a, b) { a }; f(1)", "TypeError: fn() takes exactly 2 argument (1 given)"},
+		{"f = fn(a) { a }; f(1, 2)", "TypeError: fn() takes exactly 1 argument (2 given)"},
+		{"f = fn() { f() }; f()", "RecursionError: maximum recursion depth exceeded"},
+		{`"a" * 100000000000`, "OverflowError: repeat count too large: 100000000000"},
+		{`find([1], len)`, "TypeError: find() expected argument #2 to be comparable got `builtin`"},
+		{"for i = 0; i < 3; i = i + 1 { undefined }", "identifier not found: undefined"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: no error object returned. got=%T(%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != tt.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q",
+				tt.expectedMessage, errObj.Message)
+		}
+	}
+}
+
+func TestComparisonWithoutOrdering(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"f = fn() {}; f == f", true},
+		{"fn() {} == fn() {}", false},
+		{"len != len", false},
+		{`{"a": fn() {}} == {"a": 1}`, false},
+		{`{"a": 1, "b": 2} == {"b": 2, "a": 1}`, true},
+	}
+
+	for _, tt := range tests {
+		testBooleanObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestEmptyBlocksAndReturns(t *testing.T) {
+	tests := []string{
+		"x = if true {}; x",
+		"f = fn() { return }; f()",
+		"f = fn() {}; f()",
+	}
+
+	for _, tt := range tests {
+		testNullObject(t, testEval(tt))
+	}
+
+	testIntegerObject(t, testEval("f = fn() { for true { return 5 } }; f()"), 5)
+}
+
+func TestRunRecoversPanics(t *testing.T) {
+	l := lexer.NewLexer([]byte("x = 1;\nx + boom()"))
+	p := parser.NewParser()
+	program, err := p.Parse(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := object.NewEnvironment()
+	env.Set("boom", &object.Builtin{Name: "boom", Fn: func(env *object.Environment, args ...object.Object) object.Object {
+		panic("boom")
+	}})
+
+	evaluated := Run(program.(*ast.Program), env)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+	}
+
+	expected := "InternalError: boom at 2:9"
+	if errObj.Message != expected {
+		t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+	}
+}
+
+func FuzzEval(f *testing.F) {
+	object.Stdin = strings.NewReader("")
+	object.Stdout = ioutil.Discard
+	object.ExitFn = func(int) {}
+
+	depth := MaxDepth
+	MaxDepth = 16
+	f.Cleanup(func() { MaxDepth = depth })
+
+	seeds := []string{
+		"5 % 0",
+		"1 << -1",
+		"fn() {} < fn() {}",
+		"f = fn(a, b) { a }; f(1)",
+		`{"a": fn() {}} == {"a": fn() {}}`,
+		"f = fn() { f() }; f()",
+		"x = if true {}; x + 1",
+		`"a" * -1`,
+		"[1, 2] * 3",
+		"find([fn() {}], 1)",
+		"min([len, 1])",
+		"pow(2, 1 << 70)",
+		"chr(-1)",
+		"{,}.a.b",
+		"a = [1]; a[5] = 2",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	defer func(clock object.TimeSource) { object.Clock = clock }(object.Clock)
+
+	// Loops and blocking builtins may legitimately run forever, so they are
+	// not fuzzed.
+	blocking := []string{"for", "range", "recv", "send", "select", "wait", "serve", "http", "input"}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		if len(input) > 256 {
+			t.Skip()
+		}
+		for _, name := range blocking {
+			if strings.Contains(input, name) {
+				t.Skip()
+			}
+		}
+
+		l := lexer.NewLexer([]byte(input))
+		p := parser.NewParser()
+		program, err := p.Parse(l)
+		if err != nil {
+			return
+		}
+
+		// time.sleep only advances the clock.
+		object.Clock = object.NewManualClock(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC))
+
+		done := make(chan object.Object, 1)
+		go func() { done <- Run(program.(*ast.Program), object.NewEnvironment()) }()
+		select {
+		case result := <-done:
+			if errObj, ok := result.(*object.Error); ok && strings.HasPrefix(errObj.Message, "InternalError:") {
+				t.Fatalf("evaluator panicked on %q: %s", input, errObj.Message)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("evaluating %q did not finish", input)
+		}
+	})
+}
+
+func TestNumericSemantics(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		// floor division
+		{"7 // 2", "3"},
+		{"-7 // 2", "-4"},
+		{"7 // -2", "-4"},
+		{"-7 // -2", "3"},
+		{"x = -9223372036854775807 - 1; x // -1", "9223372036854775808"},
+		{"(1 << 64) // -3", "-6148914691236517206"},
+		{"7.5 // 2", "3"},
+		{"-7.5 // 2", "-4"},
+		{"7 // 0", "division by zero: 7 // 0"},
+		{"7.0 // 0", "division by zero: 7.000000 // 0.000000"},
+
+		// modulo follows the sign of the divisor
+		{"7 % 3", "1"},
+		{"-7 % 3", "2"},
+		{"7 % -3", "-2"},
+		{"-7 % -3", "-1"},
+		{"(0 - (1 << 64)) % 10", "4"},
+		{"-7.5 % 2", "0.5"},
+		{"7.5 % -2", "-0.5"},
+		{"divmod(-7, 2)", "[-4, 1]"},
+		{"divmod(7, -2)", "[-4, -1]"},
+
+		// exponent
+		{"2 ** 10", "1024"},
+		{"2 ** 100", "1267650600228229401496703205376"},
+		{"8 ** 4611686018427387904", "OverflowError: pow() result too large"},
+		{"3 ** (1 << 30)", "OverflowError: pow() result too large"},
+		{"(1 << 100) ** 0", "1"},
+		{"2 ** -1", "0.5"},
+		{"4 ** 0.5", "2"},
+		{"2.0 ** 3", "8"},
+		{"-2 ** 2", "-4"},
+		{"2 ** 3 ** 2", "512"},
+		{"2 * 3 ** 2", "18"},
+		{"0 ** 0", "1"},
+		{"0 ** -1", "division by zero: 0 ** -1"},
+		{"0.0 ** -0.5", "division by zero: 0 ** -0.5"},
+		{"(-8) ** 0.5", "ValueError: math domain error"},
+		{"(-8) ** (1 / 3)", "ValueError: math domain error"},
+		{"(-2.0) ** 3", "-8"},
+		{"nan ** 2", "nan"},
+		{"inf ** -1", "0"},
+		{"10.0 ** 400", "inf"},
+		{"pow(0, -2)", "division by zero: 0 ** -2"},
+
+		// mixed int/float bitwise operations are rejected
+		{"1 | 1.0", "TypeError: unsupported operand types for |: int and float"},
+		{"1.0 << 2", "TypeError: unsupported operand types for <<: float and int"},
+		{"1.0 & 1.0", "TypeError: unsupported operand types for &: float and float"},
+
+		// inf and nan
+		{"inf", "inf"},
+		{"-inf", "-inf"},
+		{"nan", "nan"},
+		{"inf - inf", "nan"},
+		{"1 / inf", "0"},
+		{"inf > 1 << 2000", "true"},
+		{"-inf < 0 - (1 << 2000)", "true"},
+		{"inf == inf", "true"},
+		{"nan == nan", "false"},
+		{"nan != nan", "true"},
+		{"nan < 1", "false"},
+		{"nan >= 1", "false"},
+		{"1 == nan", "false"},
+		{"x = nan; x == x", "false"},
+		{"sorted([3, nan, 1, -inf])", "[nan, -inf, 1, 3]"},
+		{"int(inf)", "ValueError: cannot convert float inf to int"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestMathModule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"math.pi", "3.141592653589793"},
+		{"math.e", "2.718281828459045"},
+		{"math.inf", "inf"},
+		{"math.isnan(math.nan)", "true"},
+		{"math.isinf(-math.inf)", "true"},
+		{"math.isinf(1 << 2000)", "false"},
+		{"math.sqrt(16)", "4"},
+		{"math.sqrt(2.25)", "1.5"},
+		{"math.sqrt(-1)", "ValueError: math domain error"},
+		{"math.sqrt(\"4\")", "TypeError: math.sqrt() expected argument #1 to be `int` or `float` got `str`"},
+		{"math.sqrt()", "TypeError: math.sqrt() takes exactly 1 argument (0 given)"},
+		{"math.exp(0)", "1"},
+		{"math.log(math.e)", "1"},
+		{"math.log(8, 2)", "3"},
+		{"math.log(0)", "ValueError: math domain error"},
+		{"math.log(10, 1)", "ZeroDivisionError: math.log() base must not be 1"},
+		{"math.log2(1 << 2000)", "2000"},
+		{"math.log10(1000)", "3"},
+		{"math.sin(0)", "0"},
+		{"math.cos(0)", "1"},
+		{"math.atan2(1, 1) * 4 == math.pi", "true"},
+		{"math.asin(2)", "ValueError: math domain error"},
+		{"math.hypot(3, 4)", "5"},
+		{"math.floor(-2.5)", "-3"},
+		{"math.ceil(2.1)", "3"},
+		{"math.trunc(-2.7)", "-2"},
+		{"math.floor(1e30)", "1000000000000000019884624838656"},
+		{"math.floor(7)", "7"},
+		{"math.floor(math.nan)", "ValueError: cannot convert float nan to int"},
+		{"math.round(2.5)", "2"},
+		{"math.round(3.5)", "4"},
+		{"math.round(3.14159, 2)", "3.14"},
+		{"math.gcd(12, 18)", "6"},
+		{"math.gcd(-12, 18.0)", "6"},
+		{"math.gcd(1.5, 3)", "ValueError: math.gcd() expected an integral value got 1.5"},
+		{"math.lcm(4, 6)", "12"},
+		{"math.lcm(4, 0)", "0"},
+		{"math.isqrt(17)", "4"},
+		{"math.isqrt(1 << 100)", "1125899906842624"},
+		{"math.isqrt(-1)", "ValueError: math.isqrt() argument must be nonnegative"},
+		{"math.factorial(5)", "120"},
+		{"math.factorial(25)", "15511210043330985984000000"},
+		{"math.factorial(-1)", "ValueError: math.factorial() not defined for negative values"},
+		{"math.factorial(1 << 20)", "OverflowError: math.factorial() argument too large"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestModulesPerInterpreter(t *testing.T) {
+	env := object.NewEnvironment()
+	testEvalEnv(t, `math.pi = 3; delete(time, "now"); update(http, {"get": 1})`, env)
+
+	if result := testEvalEnv(t, `[math.pi, http.get, time.now]`, env); result.String() != "[3, 1, null]" {
+		t.Errorf("module changes were not kept. got=%s", result.String())
+	}
+
+	other := object.NewEnvironment()
+	result := testEvalEnv(t, `[math.pi, typeof(time.now), typeof(http.get)]`, other)
+	if expected := `[3.141592653589793, "builtin", "builtin"]`; result.String() != expected {
+		t.Errorf("module changes leaked into another interpreter. got=%s, want=%s", result.String(), expected)
+	}
+}
+
+func TestFileSystem(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(roots []string) { object.Roots = roots }(object.Roots)
+	object.Roots = []string{root}
+
+	path := func(name string) string {
+		return fmt.Sprintf("%q", filepath.Join(root, name))
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"write_file(" + path("a.txt") + ", \"one\\n\")", "null"},
+		{"append_file(" + path("a.txt") + ", \"two\\n\")", "null"},
+		{"read_file(" + path("a.txt") + ")", "one\ntwo\n"},
+		{"exists(" + path("a.txt") + ")", "true"},
+		{"exists(" + path("b.txt") + ")", "false"},
+		{"mkdir(" + path("sub/dir") + "); listdir(" + path("") + ")", `["a.txt", "link", "sub"]`},
+		{"s = stat(" + path("a.txt") + "); [s.name, s.size, s.is_dir]", `["a.txt", 8, false]`},
+		{"stat(" + path("sub") + ").is_dir", "true"},
+		{"f = open(" + path("a.txt") + "); [readline(f), readline(f), readline(f)]", `["one", "two", null]`},
+		{"f = open(" + path("c.txt") + ", \"w\"); write(f, \"abc\"); close(f); read_file(" + path("c.txt") + ")", "abc"},
+		{"f = open(" + path("c.txt") + "); close(f); readline(f)", "ValueError: readline() I/O operation on closed file"},
+		{"f = open(" + path("c.txt") + "); write(f, \"x\")", fmt.Sprintf("IOError: write() file %q not open for writing", filepath.Join(root, "c.txt"))},
+		{"open(" + path("c.txt") + ", \"x\")", "ValueError: open() invalid mode \"x\""},
+		{"f = open(" + path("a.txt") + "); [f.readline(), f.readline(), f.mode]", `["one", "two", "r"]`},
+		{"f = open(" + path("c.txt") + ", \"w\"); f.write(\"xyz\"); f.close(); read_file(" + path("c.txt") + ")", "xyz"},
+		{"f = open(" + path("c.txt") + "); f.close(); f.readline()", "ValueError: readline() I/O operation on closed file"},
+		{"remove(" + path("c.txt") + "); exists(" + path("c.txt") + ")", "false"},
+		{"read_file(" + path("missing") + ")", "FileNotFoundError: open " + filepath.Join(root, "missing") + ": no such file or directory"},
+		{"remove(" + path("") + ")", fmt.Sprintf("PermissionError: remove() cannot remove root %q", root)},
+		{"read_file(" + path("../x") + ")", fmt.Sprintf("PermissionError: read_file() path %q is outside of the allowed roots", filepath.Join(root, "../x"))},
+		{"read_file(" + path("link/secret") + ")", fmt.Sprintf("PermissionError: read_file() path %q is outside of the allowed roots", filepath.Join(root, "link/secret"))},
+		{"write_file(" + path("link/new") + ", \"\")", fmt.Sprintf("PermissionError: write_file() path %q is outside of the allowed roots", filepath.Join(root, "link/new"))},
+		{"read_file(1)", "TypeError: read_file() expected argument #1 to be `str` got `int`"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+
+	object.Roots = nil
+	evaluated := testEval("exists(" + path("a.txt") + ")")
+	if expected := "PermissionError: exists() file system access is disabled"; evaluated.String() != expected {
+		t.Errorf("wrong result. got=%s, want=%s", evaluated.String(), expected)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`json_encode({"b": [1, 2.5, 3.0], "a": null, "c": {"d": true, "e": "x\"y"}})`,
+			`{"b":[1,2.5,3.0],"a":null,"c":{"d":true,"e":"x\"y"}}`},
+		{`json_encode([1, {"a": []}, json_decode("{}")], 2)`, "[\n  1,\n  {\n    \"a\": []\n  },\n  {}\n]"},
+		{`json_encode({"a": 1}, "\t")`, "{\n\t\"a\": 1\n}"},
+		{`json_encode(1 << 70)`, "1180591620717411303424"},
+		{`json_encode("<&>")`, `"<&>"`},
+		{`json_encode(fn(x) { x })`, "TypeError: json_encode() object of type `fn` is not JSON serializable"},
+		{`json_encode({1: 2})`, "TypeError: json_encode() keys must be `str` got `int`"},
+		{`json_encode(nan)`, "ValueError: json_encode() float nan is not JSON compliant"},
+		{`a = [1]; a[0] = a; json_encode(a)`, "ValueError: json_encode() circular reference detected"},
+		{`a = [1]; json_encode([a, a])`, "[[1],[1]]"},
+		{`json_encode(1, 1.5)`, "TypeError: json_encode() expected argument #2 to be `int` or `str` got `float`"},
+
+		{`json_decode("[1, 2.5, 1e3, \"x\", true, null, 123456789012345678901234567890]")`,
+			`[1, 2.5, 1000, "x", true, null, 123456789012345678901234567890]`},
+		{`typeof(json_decode("1.0"))`, "float"},
+		{`json_decode("{\"a\": {\"b\": [1]}}").a.b`, "[1]"},
+		{`x = json_decode("{\"a\": 1, \"b\": [true]}"); json_decode(json_encode(x)) == x`, "true"},
+		{`json_decode("{\"a\": 1,\n \"b\" 2}")`, "ValueError: json_decode() invalid character '2' after object key at line 2, column 6"},
+		{`json_decode("[1, 2")`, "ValueError: json_decode() unexpected end of JSON input at line 1, column 6"},
+		{`json_decode("")`, "ValueError: json_decode() unexpected end of JSON input at line 1, column 1"},
+		{`json_decode("[1] x")`, "ValueError: json_decode() unexpected data after top-level value at line 1, column 5"},
+		{`json_decode("[1e999]")`, "ValueError: json_decode() number 1e999 out of range at line 1, column 2"},
+		{`json_decode(1)`, "TypeError: json_decode() expected argument #1 to be `str` got `int`"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestRegex(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"m = re_match(`(\\d+)-(\\d+)`, \"id 12-345\"); [m.text, m.start, m.end, m.groups]", `["12-345", 3, 9, ["12", "345"]]`},
+		{"re_match(`\\d`, \"abc\")", "null"},
+		{"re_match(`(?P<year>\\d{4})-(?P<month>\\d\\d)`, \"é 2024-05\").named.year", "2024"},
+		{"re_match(`(?P<y>\\d{4})(?P<m>\\d\\d)(?P<d>\\d\\d)`, \"20240531\").named", `{"y": "2024", "m": "05", "d": "31"}`},
+		{"re_match(`(?P<year>\\d{4})-(?P<month>\\d\\d)`, \"é 2024-05\").start", "2"},
+		{"re_match(`a(x)?`, \"a\").groups", "[null]"},
+		{"m = re_find_all(`\\w+`, \"ab cd\"); [len(m), m[0].text, m[1].text, m[1].start]", `[2, "ab", "cd", 3]`},
+		{"re_find_all(`\\d`, \"abc\")", "[]"},
+		{"re_replace(`(\\w+)@(\\w+)`, \"bob@example\", \"$2 at ${1}\")", "example at bob"},
+		{"re_replace(`\\d+`, \"a1b22\", fn(m) { str(int(m.text) * 2) })", "a2b44"},
+		{"re_replace(`\\d+`, \"a1\", fn(m) { 1 })", "TypeError: re_replace() expected replacement function to return `str` got `int`"},
+		{"re_replace(`\\d+`, \"a1\", fn(m) { m.missing.x })", "null does not support selection"},
+		{"re_replace(`\\d+`, \"a1\", upper)", "TypeError: upper() expected argument #1 to be `str` got `hash`"},
+		{"re_split(`\\s*,\\s*`, \"a , b,c\")", `["a", "b", "c"]`},
+		{"re_split(`,`, \"a,b,c\", 2)", `["a", "b,c"]`},
+		{"r = re_compile(`^[a-z]+$`); [r, re_match(r, \"abc\").text, re_match(r, \"ab1\")]", `[<regex "^[a-z]+$">, "abc", null]`},
+		{"re_compile(`(`)", "ValueError: re_compile() invalid pattern: error parsing regexp: missing closing ): `(`"},
+		{"r = re_compile(`\\d+`); [r.pattern, r.match(\"a12\").text, r.find_all(\"1 2\").len(), r.split(\"a1b\"), r.replace(\"a1\", \"#\")]", `["\\d+", "12", 2, ["a", "b"], "a#"]`},
+		{"re_compile(`a`).missing", "AttributeError: regex has no member missing"},
+		{"re_match(`(`, \"\")", "ValueError: re_match() invalid pattern: error parsing regexp: missing closing ): `(`"},
+		{"re_match(1, \"\")", "TypeError: re_match() expected argument #1 to be `str` or `regex` got `int`"},
+		{"re_match(\"a\", 1)", "TypeError: re_match() expected argument #2 to be `str` got `int`"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestTimeModule(t *testing.T) {
+	defer func(clock object.TimeSource) { object.Clock = clock }(object.Clock)
+	clock := object.NewManualClock(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC))
+	object.Clock = clock
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"time.now()", "2024-03-01T12:30:00Z"},
+		{"time.clock()", "0"},
+		{"time.sleep(1500); [time.now(), time.clock()]", "[2024-03-01T12:30:01.5Z, 1.5]"},
+		{"time.sleep(-1)", "ValueError: time.sleep() length must be non-negative"},
+		{"time.sleep(\"1\")", "TypeError: time.sleep() expected argument #1 to be `int`, `float` or `duration` got `str`"},
+		{"time.parse(\"2024-02-29\", time.date_only)", "2024-02-29T00:00:00Z"},
+		{"time.parse(\"2024-02-29 10:00:00\", time.date_time, \"Europe/Paris\")", "2024-02-29T10:00:00+01:00"},
+		{"time.parse(\"2024-02-30\", time.date_only)", "ValueError: time.parse() parsing time \"2024-02-30\": day out of range"},
+		{"time.parse(\"x\", time.date_only, \"Nowhere/City\")", "ValueError: time.parse() unknown time zone \"Nowhere/City\""},
+		{"time.format(time.from_unix(0), time.date_time)", "1970-01-01 00:00:00"},
+		{"time.format(time.tz(time.from_unix(0), \"Asia/Tokyo\"), time.rfc3339)", "1970-01-01T09:00:00+09:00"},
+		{"time.unix(time.from_unix(1700000000))", "1700000000"},
+		{"t = time.from_unix(1700000000); [t.unix(), t.tz(\"Asia/Tokyo\").format(time.date_time)]", `[1700000000, "2023-11-15 07:13:20"]`},
+		{"(90 * time.second).seconds()", "90"},
+		{"time.from_unix(1.25)", "1970-01-01T00:00:01.25Z"},
+
+		// duration arithmetic
+		{"time.from_unix(0) + 90 * time.minute", "1970-01-01T01:30:00Z"},
+		{"time.from_unix(0) - time.hour", "1969-12-31T23:00:00Z"},
+		{"time.from_unix(60) - time.from_unix(0)", "1m0s"},
+		{"time.hour + time.duration(\"30m\")", "1h30m0s"},
+		{"time.hour / time.minute", "60"},
+		{"time.hour // (7 * time.minute)", "8"},
+		{"time.hour % (7 * time.minute)", "4m0s"},
+		{"time.hour / 4", "15m0s"},
+		{"1.5 * time.second", "1.5s"},
+		{"-time.second", "-1s"},
+		{"time.seconds(time.millisecond * 250)", "0.25"},
+		{"time.hour * (1 << 40)", "OverflowError: duration out of range"},
+		{"time.hour / 0", "division by zero: 1h0m0s / 0"},
+		{"time.hour / (time.second * 0)", "division by zero: 1h0m0s / 0s"},
+		{"time.hour // (time.second * 0)", "division by zero: 1h0m0s // 0s"},
+		{"time.from_unix(0) + time.from_unix(0)", "unknown operator: time + time"},
+		{"time.duration(\"1x\")", "ValueError: time.duration() time: unknown unit \"x\" in duration \"1x\""},
+
+		// comparison and hashing
+		{"time.from_unix(0) < time.from_unix(1)", "true"},
+		{"time.from_unix(0) == time.tz(time.from_unix(0), \"Asia/Tokyo\")", "true"},
+		{"time.minute > time.second", "true"},
+		{"h = {time.from_unix(0): 1}; h[time.tz(time.from_unix(0), \"Asia/Tokyo\")]", "1"},
+		{"h = {time.minute: 1}; h[60 * time.second]", "1"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	run := func(input string, env *object.Environment) string {
+		return testEvalEnv(t, input, env).String()
+	}
+
+	input := "[random(), randint(1, 6), randint(0, 1 << 80), choice([1, 2, 3]), shuffle([1, 2, 3, 4]), sample([1, 2, 3, 4], 2)]"
+
+	first, second := object.NewEnvironment(), object.NewEnvironment()
+	first.Seed(42)
+	second.Seed(42)
+	expected := run(input, first)
+	run("random()", first)
+	if got := run(input, second); got != expected {
+		t.Errorf("generators are not independent. got=%s, want=%s", got, expected)
+	}
+	if got := run("seed(42); "+input, first); got != expected {
+		t.Errorf("seed() did not reset the generator. got=%s, want=%s", got, expected)
+	}
+
+	env := object.NewEnvironment()
+	for i := 0; i < 100; i++ {
+		result := run("x = randint(-2, 2); x >= -2 && x <= 2", env)
+		if result != "true" {
+			t.Fatalf("randint() out of range")
+		}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"len(sample([1, 2, 3], 3))", "3"},
+		{"sorted(shuffle([3, 1, 2]))", "[1, 2, 3]"},
+		{"a = [1, 2]; shuffle(a) == a", "true"},
+		{"randint(2, 1)", "ValueError: randint() empty range [2, 1]"},
+		{"choice([])", "IndexError: choice() from an empty array"},
+		{"sample([1], 2)", "ValueError: sample() larger than population or is negative"},
+		{"seed(1.5)", "TypeError: seed() expected argument #1 to be `int` got `float`"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestProcess(t *testing.T) {
+	evaluated := testEval(`getenv("HOME")`)
+	if expected := "PermissionError: getenv() process access is disabled"; evaluated.String() != expected {
+		t.Errorf("wrong result. got=%s, want=%s", evaluated.String(), expected)
+	}
+
+	defer func(allow bool) { object.AllowProcess = allow }(object.AllowProcess)
+	object.AllowProcess = true
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	defer os.Unsetenv("ULANG_TEST")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`getenv("ULANG_TEST")`, "null"},
+		{`getenv("ULANG_TEST", "default")`, "default"},
+		{`setenv("ULANG_TEST", "1"); [getenv("ULANG_TEST"), environ().ULANG_TEST]`, `["1", "1"]`},
+		{fmt.Sprintf(`chdir(%q); cwd()`, dir), dir},
+		{`chdir("/does/not/exist")`, "FileNotFoundError: chdir /does/not/exist: no such file or directory"},
+		{`r = exec("sh", ["-c", "echo out; echo err >&2; exit 3"]); [r.stdout, r.stderr, r.code]`, `["out\n", "err\n", 3]`},
+		{`exec("cat", [], {"stdin": "piped"}).stdout`, "piped"},
+		{`exec("sh", ["-c", "echo $ULANG_TEST $EXTRA"], {"env": {"EXTRA": 2}}).stdout`, "1 2\n"},
+		{fmt.Sprintf(`exec("pwd", [], {"cwd": %q}).stdout`, wd), wd + "\n"},
+		{`exec("sleep", ["5"], {"timeout": 50})`, "TimeoutError: exec() command timed out after 50ms"},
+		{`exec("/does/not/exist")`, "FileNotFoundError: fork/exec /does/not/exist: no such file or directory"},
+		{`exec("true", [], {"cwd": 1})`, "TypeError: exec() invalid type `int` for option \"cwd\""},
+		{`exec("true", [], {"shell": true})`, "ValueError: exec() unknown option \"shell\""},
+		{`at_exit(1)`, "TypeError: at_exit() expected argument #1 to be `fn` got `int`"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestExitHooks(t *testing.T) {
+	defer func(allow bool, exit func(int), stdout io.Writer) {
+		object.AllowProcess, object.ExitFn, object.Stdout = allow, exit, stdout
+	}(object.AllowProcess, object.ExitFn, object.Stdout)
+
+	var out strings.Builder
+	var status int
+	object.AllowProcess = true
+	object.Stdout = &out
+	object.ExitFn = func(code int) {
+		out.WriteString("exit\n")
+		status = code
+	}
+
+	testEval(`at_exit(fn() { print("first") }); at_exit(fn() { print("second") }); exit(2)`)
+	if expected := "second\nfirst\nexit\n"; out.String() != expected || status != 2 {
+		t.Errorf("wrong exit sequence. got=%q (%d), want=%q (2)", out.String(), status, expected)
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "%s %s %s", r.URL.Path, r.Header.Get("X-Token"), body)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`r = http.get(URL + "/a"); [r.status, r.body, r.headers["X-Method"]]`, `[201, "/a  ", "GET"]`},
+		{`http.get(URL + "/b", {"headers": {"X-Token": "secret"}}).body`, "/b secret "},
+		{`http.post(URL + "/c", "payload").body`, "/c  payload"},
+		{`r = http.request("put", URL + "/d", {"body": "x"}); [r.headers["X-Method"], r.body]`, `["PUT", "/d  x"]`},
+		{`http.get(URL + "/slow", {"timeout": 20})`, "TimeoutError: http.get() request timed out after 20ms"},
+		{`http.get(URL, {"retries": 1})`, "ValueError: http.get() unknown option \"retries\""},
+		{`http.get(URL, {"headers": 1})`, "TypeError: http.get() expected headers to be `hash` got `int`"},
+		{`http.request("GET", "::")`, "ValueError: http.request() parse \"::\": missing protocol scheme"},
+	}
+
+	for _, tt := range tests {
+		env := object.NewEnvironment()
+		env.Set("URL", &object.String{Value: server.URL})
+		evaluated := testEvalEnv(t, tt.input, env)
+		if evaluated.String() != tt.expected {
+			t.Errorf("%s: wrong result. got=%s, want=%s", tt.input, evaluated.String(), tt.expected)
+		}
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	env := object.NewEnvironment()
+	handler := testEvalEnv(t, `fn(req) {
+		if (req.path == "/hello") {
+			return "hello " + req.query.name;
+		};
+		if (req.path == "/empty") {
+			return null;
+		};
+		if (req.path == "/error") {
+			return 1 + "a";
+		};
+		{"status": 202, "headers": {"X-Method": req.method}, "body": req.body};
+	}`, env)
+
+	server := httptest.NewServer(builtins.HTTPHandler(env, handler))
+	defer server.Close()
+
+	tests := []struct {
+		method   string
+		path     string
+		status   int
+		expected string
+	}{
+		{"GET", "/hello?name=ulang", 200, "hello ulang"},
+		{"GET", "/empty", 204, ""},
+		{"GET", "/error", 500, "Internal Server Error\n"},
+		{"POST", "/echo", 202, "body"},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader("body"))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status || string(body) != tt.expected {
+			t.Errorf("%s %s: wrong response. got=%d %q, want=%d %q", tt.method, tt.path, resp.StatusCode, body, tt.status, tt.expected)
+		}
+		if tt.status == 202 && resp.Header.Get("X-Method") != tt.method {
+			t.Errorf("%s %s: wrong header. got=%q", tt.method, tt.path, resp.Header.Get("X-Method"))
+		}
+	}
+}
+
+func TestHTTPHandlerPanic(t *testing.T) {
+	handler := &object.Builtin{Name: "handler", Fn: func(env *object.Environment, args ...object.Object) object.Object {
+		panic("boom")
+	}}
+	server := httptest.NewServer(builtins.HTTPHandler(object.NewEnvironment(), handler))
+	defer server.Close()
+
+	// The second request would block if the first one had not released
+	// the handler.
+	for i := 0; i < 2; i++ {
+		resp, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != 500 {
+			t.Errorf("wrong status. got=%d, want=500", resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package eval
+
+import (
+	"runtime"
+
+	"github.com/Ars2014/ulang/ast"
+	"github.com/Ars2014/ulang/object"
+)
+
+// generator runs the body of a generator function in a goroutine of its
+// own. The goroutine only runs while the consumer waits for the next value,
+// so the body is never evaluated concurrently with its consumer.
+type generator struct {
+	resume  chan bool          // closed to stop the generator while suspended
+	abandon chan struct{}      // closed once the generator is unreachable
+	values  chan object.Object // closed when the body has returned
+	started bool
+}
+
+// errGeneratorClosed unwinds the body of a generator which is closed while
+// suspended at a yield.
+var errGeneratorClosed = &object.Error{Message: "generator closed"}
+
+// newGenerator returns an iterator over the values yielded by evaluating
+// the body of fn in env. The body starts running when the first value is
+// requested.
+func newGenerator(fn *object.Function, env *object.Environment) object.Object {
+	g := &generator{
+		resume:  make(chan bool),
+		abandon: make(chan struct{}),
+		values:  make(chan object.Object),
+	}
+	env.SetYield(g.yield)
+
+	it := object.NewIter("generator", func() (object.Object, bool) {
+		if !g.started {
+			g.started = true
+			go g.run(fn, env)
+		} else {
+			g.resume <- true
+		}
+		value, ok := <-g.values
+		return value, ok
+	}, g.close)
+	// Consumers close generators they stop early. One which is dropped
+	// while suspended instead has its goroutine exit without evaluating
+	// the rest of the body, as the finalizer does not hold the interpreter.
+	runtime.SetFinalizer(it, func(*object.Iter) { close(g.abandon) })
+	return it
+}
+
+func (g *generator) run(fn *object.Function, env *object.Environment) {
+	defer close(g.values)
+	defer func() {
+		if r := recover(); r != nil {
+			g.values <- newInternalError(r)
+		}
+	}()
+
+	result := Eval(fn.Body, env)
+	if isError(result) && result != errGeneratorClosed {
+		g.values <- result
+	}
+}
+
+// yield passes value to the consumer and suspends the body until the next
+// value is requested. It reports false if the generator was closed instead.
+func (g *generator) yield(value object.Object) bool {
+	g.values <- value
+	select {
+	case ok := <-g.resume:
+		return ok
+	case <-g.abandon:
+		runtime.Goexit()
+		return false
+	}
+}
+
+// close stops a suspended generator and waits for its goroutine to exit.
+func (g *generator) close() {
+	if !g.started {
+		return
+	}
+	close(g.resume)
+	for range g.values {
+	}
+}
+
+func evalYieldStatement(node *ast.YieldStatement, env *object.Environment) object.Object {
+	yield := env.Yield()
+	if yield == nil {
+		return newError("SyntaxError: yield outside of a generator function")
+	}
+
+	var value object.Object = NULL
+	if node.Value != nil {
+		value = Eval(node.Value, env)
+		if isError(value) {
+			return value
+		}
+	}
+
+	if !yield(value) {
+		return errGeneratorClosed
+	}
+	return NULL
+}
@@ -0,0 +1,120 @@
+package eval
+
+import (
+	"github.com/Ars2014/ulang/ast"
+	"github.com/Ars2014/ulang/object"
+)
+
+// destructureSequence assigns the elements of an iterable to the targets of
+// an array or tuple pattern in order. A spread target collects the elements
+// left over in an array.
+func destructureSequence(env *object.Environment, targets ast.ExpressionList, value object.Object) object.Object {
+	rest := -1
+	for i, target := range targets {
+		if _, ok := target.(*ast.SpreadElement); ok {
+			rest = i
+		}
+	}
+
+	iterable, err := iterableOf(env, value)
+	if err != nil {
+		return err
+	}
+	iter := iterable.Iter()
+
+	// Without a spread target one element more than needed is taken, so
+	// that an infinite iterator is not consumed forever.
+	want := len(targets)
+	if rest >= 0 {
+		want = -1
+	}
+	elements := []object.Object{}
+	for want < 0 || len(elements) <= want {
+		el, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if isError(el) {
+			return el
+		}
+		elements = append(elements, el)
+	}
+	object.CloseIterator(iter)
+
+	if rest < 0 {
+		switch {
+		case len(elements) < len(targets):
+			return newError("ValueError: not enough values to unpack (expected %d, got %d)", len(targets), len(elements))
+		case len(elements) > len(targets):
+			return newError("ValueError: too many values to unpack (expected %d)", len(targets))
+		}
+		for i, target := range targets {
+			if err := assign(env, target, elements[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(elements) < len(targets)-1 {
+		return newError("ValueError: not enough values to unpack (expected at least %d, got %d)", len(targets)-1, len(elements))
+	}
+	after := len(targets) - 1 - rest
+	for i, target := range targets {
+		var el object.Object
+		switch {
+		case i < rest:
+			el = elements[i]
+		case i == rest:
+			target = target.(*ast.SpreadElement).Value
+			tail := elements[rest : len(elements)-after]
+			el = &object.Array{Elements: append([]object.Object{}, tail...)}
+		default:
+			el = elements[len(elements)-len(targets)+i]
+		}
+		if err := assign(env, target, el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// destructureNames assigns the names of a pattern like {name, age} the
+// values of the keys of a hash with those names, or null for missing keys.
+// Other objects are looked up by selection, such as the fields of an
+// instance.
+func destructureNames(env *object.Environment, names ast.ExpressionList, value object.Object) object.Object {
+	for _, name := range names {
+		ident := name.(*ast.Identifier)
+		var member object.Object
+		if hash, ok := value.(*object.Hash); ok {
+			member = evalHashIndexExpression(hash, &object.String{Value: ident.Value})
+		} else {
+			member = evalSelectorExpression(value, ident)
+		}
+		if isError(member) {
+			return member
+		}
+		env.Set(ident.Value, member)
+	}
+	return nil
+}
+
+// destructureKeys assigns the values of a pattern like {"key": target} the
+// result of indexing the value with each key.
+func destructureKeys(env *object.Environment, pairs ast.ExpressionMap, value object.Object) object.Object {
+	for _, pair := range pairs {
+		key := Eval(pair.Key, env)
+		if isError(key) {
+			return key
+		}
+		el := getIndex(env, value, key)
+		if isError(el) {
+			return el
+		}
+		if err := assign(env, pair.Value, el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package eval
+
+import (
+	"math"
+
+	"github.com/Ars2014/ulang/ast"
+	"github.com/Ars2014/ulang/object"
+)
+
+// normalizeIndex resolves an index into a sequence of the given length, where
+// negative indexes count from the end, and reports whether it is in range.
+func normalizeIndex(index *object.Integer, length int) (int, bool) {
+	if index.IsBig() {
+		return 0, false
+	}
+	idx := index.Value
+	if idx < 0 {
+		idx += int64(length)
+	}
+	if idx < 0 || idx >= int64(length) {
+		return 0, false
+	}
+	return int(idx), true
+}
+
+// indexError is the result of reading an index out of range: an IndexError
+// with object.StrictIndex and otherwise the given value.
+func indexError(typ object.Type, value object.Object) object.Object {
+	if object.StrictIndex {
+		return newError("IndexError: %s index out of range", typ)
+	}
+	return value
+}
+
+// sliceBounds holds the bounds of a slice as evaluated, nil where they were
+// left out.
+type sliceBounds struct {
+	start, end, step object.Object
+}
+
+func evalSliceBounds(node *ast.SliceExpression, env *object.Environment) (sliceBounds, object.Object) {
+	var bounds sliceBounds
+	for _, b := range []struct {
+		expr ast.Expression
+		obj  *object.Object
+	}{
+		{node.Start, &bounds.start},
+		{node.End, &bounds.end},
+		{node.Step, &bounds.step},
+	} {
+		if b.expr == nil {
+			continue
+		}
+		obj := Eval(b.expr, env)
+		if isError(obj) {
+			return bounds, obj
+		}
+		*b.obj = obj
+	}
+	return bounds, nil
+}
+
+// clampInt returns the value of a slice bound, saturating big integers.
+func clampInt(obj *object.Integer) int64 {
+	if !obj.IsBig() {
+		return obj.Value
+	}
+	if obj.Sign() < 0 {
+		return math.MinInt64
+	}
+	return math.MaxInt64
+}
+
+// indices resolves the bounds of a slice of a sequence of the given length
+// the way Python does. It returns the index of the first element, the step
+// and the number of elements in the slice.
+func (b sliceBounds) indices(length int) (start, step int64, count int, err *object.Error) {
+	var bounds [3]*object.Integer
+	for i, obj := range []object.Object{b.start, b.end, b.step} {
+		switch obj := obj.(type) {
+		case nil, *object.Null:
+		case *object.Integer:
+			bounds[i] = obj
+		default:
+			return 0, 0, 0, newError("TypeError: slice indices must be integers or null got `%s`", obj.Type())
+		}
+	}
+
+	step = 1
+	if bounds[2] != nil {
+		step = clampInt(bounds[2])
+		if step == 0 {
+			return 0, 0, 0, newError("ValueError: slice step cannot be zero")
+		}
+		if step == math.MinInt64 {
+			step = -math.MaxInt64
+		}
+	}
+
+	n := int64(length)
+	resolve := func(bound *object.Integer, omitted int64) int64 {
+		if bound == nil {
+			return omitted
+		}
+		idx := clampInt(bound)
+		if idx < 0 {
+			idx += n
+			if idx < 0 {
+				if step < 0 {
+					return -1
+				}
+				return 0
+			}
+		} else if idx >= n {
+			if step < 0 {
+				return n - 1
+			}
+			return n
+		}
+		return idx
+	}
+
+	var end int64
+	if step < 0 {
+		start, end = resolve(bounds[0], n-1), resolve(bounds[1], -1)
+		if end < start {
+			count = int((start-end-1)/-step + 1)
+		}
+	} else {
+		start, end = resolve(bounds[0], 0), resolve(bounds[1], n)
+		if start < end {
+			count = int((end-start-1)/step + 1)
+		}
+	}
+	return start, step, count, nil
+}
+
+func sliceElements(elements []object.Object, start, step int64, count int) []object.Object {
+	result := make([]object.Object, count)
+	for k := range result {
+		result[k] = elements[start+int64(k)*step]
+	}
+	return result
+}
+
+func evalSliceExpression(left object.Object, bounds sliceBounds) object.Object {
+	switch left := left.(type) {
+	case *object.String:
+		runes := []rune(left.Value)
+		start, step, count, err := bounds.indices(len(runes))
+		if err != nil {
+			return err
+		}
+		result := make([]rune, count)
+		for k := range result {
+			result[k] = runes[start+int64(k)*step]
+		}
+		return &object.String{Value: string(result)}
+
+	case *object.Array:
+		start, step, count, err := bounds.indices(len(left.Elements))
+		if err != nil {
+			return err
+		}
+		return &object.Array{Elements: sliceElements(left.Elements, start, step, count)}
+
+	case *object.Tuple:
+		start, step, count, err := bounds.indices(len(left.Elements))
+		if err != nil {
+			return err
+		}
+		return &object.Tuple{Elements: sliceElements(left.Elements, start, step, count)}
+
+	default:
+		return newError("slice operator not supported: %s", left.Type())
+	}
+}
+
+// evalSliceAssignment replaces the elements of an array in a slice with the
+// elements of an iterable. A slice with a step other than 1 must be replaced
+// by as many elements as it has.
+func evalSliceAssignment(env *object.Environment, array *object.Array, bounds sliceBounds, value object.Object) object.Object {
+	start, step, count, err := bounds.indices(len(array.Elements))
+	if err != nil {
+		return err
+	}
+	values, errObj := elementsOf(env, value)
+	if errObj != nil {
+		return errObj
+	}
+
+	if step == 1 {
+		elements := make([]object.Object, 0, len(array.Elements)-count+len(values))
+		elements = append(elements, array.Elements[:start]...)
+		elements = append(elements, values...)
+		elements = append(elements, array.Elements[start+int64(count):]...)
+		array.Elements = elements
+		return nil
+	}
+
+	if len(values) != count {
+		return newError("ValueError: attempt to assign sequence of size %d to extended slice of size %d", len(values), count)
+	}
+	for k, v := range values {
+		array.Elements[start+int64(k)*step] = v
+	}
+	return nil
+}
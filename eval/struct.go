@@ -0,0 +1,163 @@
+package eval
+
+import (
+	"github.com/Ars2014/ulang/ast"
+	"github.com/Ars2014/ulang/object"
+)
+
+// initMethod is called by the constructor of a struct which declares it
+// instead of assigning the arguments to the fields.
+const initMethod = "init"
+
+func evalStructDeclaration(decl *ast.StructDeclaration, env *object.Environment) object.Object {
+	name := decl.Name.Value
+	if object.IsBuiltinType(object.Type(name)) {
+		return newError("TypeError: cannot declare struct with builtin type name %s", name)
+	}
+
+	methods := make(map[string]*object.Function, len(decl.Methods))
+	for _, m := range decl.Methods {
+		methods[m.Name.Value] = &object.Function{
+			Parameters: m.Function.Parameters,
+			Env:        env,
+			Body:       m.Function.Body,
+			Generator:  m.Function.IsGenerator,
+		}
+	}
+
+	s := &object.Struct{Name: name, Fields: decl.Fields, Methods: methods, Env: env}
+	env.Set(name, s)
+	return s
+}
+
+// construct creates an instance of s. Without an init method the arguments
+// are assigned to the fields in order of declaration, and the remaining
+// fields get their default values.
+func construct(env *object.Environment, s *object.Struct, args []object.Object) object.Object {
+	init, hasInit := s.Methods[initMethod]
+	if !hasInit && len(args) > len(s.Fields) {
+		return newError("TypeError: %s() takes at most %d arguments (%d given)", s.Name, len(s.Fields), len(args))
+	}
+
+	instance := &object.Instance{Struct: s, Fields: make([]object.Object, len(s.Fields))}
+	for i, field := range s.Fields {
+		switch {
+		case !hasInit && i < len(args):
+			instance.Fields[i] = args[i]
+		case field.Default != nil:
+			value := Eval(field.Default, s.Env)
+			if isError(value) {
+				return value
+			}
+			instance.Fields[i] = value
+		case hasInit:
+			instance.Fields[i] = NULL
+		default:
+			return newError("TypeError: %s() missing argument for field %s", s.Name, field.Name.Value)
+		}
+	}
+
+	if hasInit {
+		if result := callFunction(env, init, instance, args); isError(result) {
+			return result
+		}
+	}
+	return instance
+}
+
+// operatorMethods are the protocol methods implementing infix operators for
+// instances. The reflected method, e.g. __radd__, is called on the right
+// operand when the left one does not implement the operator.
+var operatorMethods = map[string]string{
+	"+":  "__add__",
+	"-":  "__sub__",
+	"*":  "__mul__",
+	"/":  "__div__",
+	"//": "__floordiv__",
+	"%":  "__mod__",
+	"**": "__pow__",
+	"|":  "__or__",
+	"&":  "__and__",
+	"^":  "__xor__",
+	"<<": "__lshift__",
+	">>": "__rshift__",
+	"==": "__eq__",
+	"<":  "__lt__",
+	"<=": "__le__",
+	">":  "__gt__",
+	">=": "__ge__",
+}
+
+// callMethod calls the named protocol method of obj if it is an instance
+// which declares it.
+func callMethod(env *object.Environment, obj object.Object, name string, args ...object.Object) (object.Object, bool) {
+	instance, ok := obj.(*object.Instance)
+	if !ok {
+		return nil, false
+	}
+	method, ok := instance.Method(name)
+	if !ok {
+		return nil, false
+	}
+	return applyFunction(env, method, args), true
+}
+
+// callPredicate is like callMethod for methods whose result is used as a
+// boolean.
+func callPredicate(env *object.Environment, obj object.Object, name string, args ...object.Object) (bool, object.Object, bool) {
+	result, ok := callMethod(env, obj, name, args...)
+	if !ok || isError(result) {
+		return false, result, ok
+	}
+	return result.Bool(), nil, true
+}
+
+// evalOperatorMethod applies an infix operator implemented by a protocol
+// method of either operand. Comparisons which are not implemented directly
+// are derived from __lt__ and __eq__.
+func evalOperatorMethod(env *object.Environment, operator string, left, right object.Object) (object.Object, bool) {
+	if operator == "!=" {
+		eq, err, ok := callPredicate(env, left, "__eq__", right)
+		if !ok || err != nil {
+			return err, ok
+		}
+		return fromNativeBoolean(!eq), true
+	}
+
+	name, ok := operatorMethods[operator]
+	if !ok {
+		return nil, false
+	}
+	if result, ok := callMethod(env, left, name, right); ok {
+		return result, true
+	}
+
+	switch operator {
+	case "==":
+		return nil, false
+	case ">", "<=", ">=":
+		lt, err, ok := callPredicate(env, left, "__lt__", right)
+		if !ok || err != nil {
+			return err, ok
+		}
+		if operator == ">=" {
+			return fromNativeBoolean(!lt), true
+		}
+
+		eq, err, ok := callPredicate(env, left, "__eq__", right)
+		if err != nil {
+			return err, true
+		}
+		if !ok {
+			eq = evalComparisonExpression("==", left, right) == TRUE
+		}
+		if operator == "<=" {
+			return fromNativeBoolean(lt || eq), true
+		}
+		return fromNativeBoolean(!lt && !eq), true
+	case "<":
+		return nil, false
+	}
+
+	return callMethod(env, right, "__r"+name[2:], left)
+}
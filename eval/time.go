@@ -0,0 +1,119 @@
+package eval
+
+import (
+	"math"
+	"time"
+
+	"github.com/Ars2014/ulang/object"
+)
+
+func isTemporal(obj object.Object) bool {
+	return obj.Type() == object.TimeType || obj.Type() == object.DurationType
+}
+
+func newDuration(value float64) object.Object {
+	if math.IsNaN(value) || value >= math.MaxInt64 || value < math.MinInt64 {
+		return newError("OverflowError: duration out of range")
+	}
+	return &object.Duration{Value: time.Duration(value)}
+}
+
+func addDurations(left, right time.Duration) object.Object {
+	sum := left + right
+	if (right > 0 && sum < left) || (right < 0 && sum > left) {
+		return newError("OverflowError: duration out of range")
+	}
+	return &object.Duration{Value: sum}
+}
+
+func multiplyDuration(d time.Duration, n *object.Integer) object.Object {
+	if n.IsBig() {
+		return newError("OverflowError: duration out of range")
+	}
+	product := d * time.Duration(n.Value)
+	if n.Value != 0 && (product/time.Duration(n.Value) != d || (n.Value == -1 && d == math.MinInt64)) {
+		return newError("OverflowError: duration out of range")
+	}
+	return &object.Duration{Value: product}
+}
+
+// evalTemporalInfixExpression implements arithmetic between times, durations
+// and numbers scaling durations.
+func evalTemporalInfixExpression(operator string, left object.Object, right object.Object) object.Object {
+	switch l := left.(type) {
+	case *object.Time:
+		switch r := right.(type) {
+		case *object.Duration:
+			switch operator {
+			case "+":
+				return &object.Time{Value: l.Value.Add(r.Value)}
+			case "-":
+				return &object.Time{Value: l.Value.Add(-r.Value)}
+			}
+		case *object.Time:
+			if operator == "-" {
+				return &object.Duration{Value: l.Value.Sub(r.Value)}
+			}
+		}
+
+	case *object.Duration:
+		switch r := right.(type) {
+		case *object.Time:
+			if operator == "+" {
+				return &object.Time{Value: r.Value.Add(l.Value)}
+			}
+		case *object.Duration:
+			switch operator {
+			case "+":
+				return addDurations(l.Value, r.Value)
+			case "-":
+				return addDurations(l.Value, -r.Value)
+			case "/", "//", "%":
+				if r.Value == 0 {
+					return newError("division by zero: %s %s %s", l.Inspect(), operator, r.Inspect())
+				}
+				if operator == "/" {
+					return &object.Float{Value: float64(l.Value) / float64(r.Value)}
+				}
+				quo, rem := object.NewInteger(int64(l.Value)).FloorDivMod(object.NewInteger(int64(r.Value)))
+				if operator == "//" {
+					return quo
+				}
+				return &object.Duration{Value: time.Duration(rem.Value)}
+			}
+		case *object.Integer:
+			switch operator {
+			case "*":
+				return multiplyDuration(l.Value, r)
+			case "/", "//":
+				if r.Sign() == 0 {
+					return newError("division by zero: %s %s %s", l.Inspect(), operator, r.Inspect())
+				}
+				quo, _ := object.NewInteger(int64(l.Value)).FloorDivMod(r)
+				return &object.Duration{Value: time.Duration(quo.Value)}
+			}
+		case *object.Float:
+			switch operator {
+			case "*":
+				return newDuration(float64(l.Value) * r.Value)
+			case "/":
+				if r.Value == 0 {
+					return newError("division by zero: %s %s %s", l.Inspect(), operator, r.Inspect())
+				}
+				return newDuration(float64(l.Value) / r.Value)
+			}
+		}
+
+	case *object.Integer:
+		if r, ok := right.(*object.Duration); ok && operator == "*" {
+			return multiplyDuration(r.Value, l)
+		}
+
+	case *object.Float:
+		if r, ok := right.(*object.Duration); ok && operator == "*" {
+			return newDuration(l.Value * float64(r.Value))
+		}
+	}
+
+	return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+}
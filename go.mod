@@ -1,8 +1,16 @@
 module github.com/Ars2014/ulang
 
-go 1.16
+go 1.18
 
 require (
 	github.com/goccmack/gocc v0.0.0-20210331093148-09606ea4d4d9
 	github.com/stretchr/testify v1.7.0
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.3.0 // indirect
+	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
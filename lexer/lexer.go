@@ -11,8 +11,8 @@ import (
 
 const (
 	NoState    = -1
-	NumStates  = 93
-	NumSymbols = 114
+	NumStates  = 130
+	NumSymbols = 177
 )
 
 type Lexer struct {
@@ -147,100 +147,163 @@ Lexer symbols:
 15: 'f'
 16: 'o'
 17: 'r'
-18: 'n'
-19: 'u'
-20: 'l'
-21: 'l'
-22: 't'
-23: 'r'
-24: 'u'
-25: 'e'
-26: 'f'
-27: 'a'
-28: 'l'
-29: 's'
-30: 'e'
-31: '|'
-32: '|'
-33: '&'
-34: '&'
-35: '!'
-36: '='
-37: '|'
-38: '^'
-39: '&'
-40: '.'
-41: '.'
-42: '{'
-43: '}'
-44: ','
-45: ':'
-46: '+'
-47: '-'
-48: '('
-49: ')'
-50: '!'
-51: '~'
-52: '['
-53: ']'
-54: '.'
-55: '='
-56: '='
-57: '!'
+18: 'i'
+19: 'n'
+20: 's'
+21: 't'
+22: 'r'
+23: 'u'
+24: 'c'
+25: 't'
+26: 'y'
+27: 'i'
+28: 'e'
+29: 'l'
+30: 'd'
+31: 'n'
+32: 'u'
+33: 'l'
+34: 'l'
+35: 'i'
+36: 'n'
+37: 'f'
+38: 'n'
+39: 'a'
+40: 'n'
+41: 't'
+42: 'r'
+43: 'u'
+44: 'e'
+45: 'f'
+46: 'a'
+47: 'l'
+48: 's'
+49: 'e'
+50: '|'
+51: '|'
+52: '&'
+53: '&'
+54: '?'
+55: '?'
+56: '?'
+57: '.'
 58: '='
-59: '<'
-60: '<'
-61: '='
-62: '>'
-63: '>'
-64: '='
-65: '~'
-66: '<'
-67: '<'
-68: '>'
-69: '>'
-70: '*'
-71: '/'
-72: '%'
-73: '/'
-74: '/'
-75: '\n'
-76: '/'
-77: '*'
-78: '*'
-79: '*'
-80: '/'
-81: '_'
-82: '0'
-83: '0'
-84: 'x'
-85: 'X'
-86: 'e'
-87: 'E'
-88: '+'
-89: '-'
-90: '`'
-91: '`'
-92: '"'
-93: '\'
-94: '"'
-95: '"'
-96: '\'
-97: 'n'
-98: '\'
-99: 'r'
-100: '\'
-101: 't'
-102: ' '
-103: '\n'
-104: '\t'
-105: '\r'
-106: 'a'-'z'
-107: 'A'-'Z'
-108: '0'-'9'
-109: '0'-'7'
-110: 'a'-'f'
-111: 'A'-'F'
-112: '1'-'9'
-113: .
+59: '.'
+60: '.'
+61: '.'
+62: '|'
+63: '^'
+64: '&'
+65: '*'
+66: '*'
+67: '.'
+68: '.'
+69: '{'
+70: '}'
+71: ','
+72: ':'
+73: '+'
+74: '-'
+75: '('
+76: ')'
+77: '!'
+78: '~'
+79: '['
+80: ']'
+81: '.'
+82: '+'
+83: '='
+84: '-'
+85: '='
+86: '*'
+87: '='
+88: '/'
+89: '='
+90: '/'
+91: '/'
+92: '='
+93: '%'
+94: '='
+95: '*'
+96: '*'
+97: '='
+98: '|'
+99: '='
+100: '&'
+101: '='
+102: '^'
+103: '='
+104: '<'
+105: '<'
+106: '='
+107: '>'
+108: '>'
+109: '='
+110: '?'
+111: '?'
+112: '='
+113: '+'
+114: '+'
+115: '-'
+116: '-'
+117: '='
+118: '='
+119: '!'
+120: '='
+121: '<'
+122: '<'
+123: '='
+124: '>'
+125: '>'
+126: '='
+127: '~'
+128: '<'
+129: '<'
+130: '>'
+131: '>'
+132: '*'
+133: '/'
+134: '/'
+135: '/'
+136: '%'
+137: '#'
+138: '\n'
+139: '/'
+140: '*'
+141: '*'
+142: '*'
+143: '/'
+144: '_'
+145: '0'
+146: '0'
+147: 'x'
+148: 'X'
+149: 'e'
+150: 'E'
+151: '+'
+152: '-'
+153: '`'
+154: '`'
+155: '"'
+156: '\'
+157: '"'
+158: '"'
+159: '\'
+160: 'n'
+161: '\'
+162: 'r'
+163: '\'
+164: 't'
+165: ' '
+166: '\n'
+167: '\t'
+168: '\r'
+169: 'a'-'z'
+170: 'A'-'Z'
+171: '0'-'9'
+172: '0'-'7'
+173: 'a'-'f'
+174: 'A'-'F'
+175: '1'-'9'
+176: .
 */
@@ -25,84 +25,92 @@ var TransTab = TransitionTable{
 			return 2
 		case r == 34: // ['"','"']
 			return 3
-		case r == 37: // ['%','%']
+		case r == 35: // ['#','#']
 			return 4
-		case r == 38: // ['&','&']
+		case r == 37: // ['%','%']
 			return 5
-		case r == 40: // ['(','(']
+		case r == 38: // ['&','&']
 			return 6
-		case r == 41: // [')',')']
+		case r == 40: // ['(','(']
 			return 7
-		case r == 42: // ['*','*']
+		case r == 41: // [')',')']
 			return 8
-		case r == 43: // ['+','+']
+		case r == 42: // ['*','*']
 			return 9
-		case r == 44: // [',',',']
+		case r == 43: // ['+','+']
 			return 10
-		case r == 45: // ['-','-']
+		case r == 44: // [',',',']
 			return 11
-		case r == 46: // ['.','.']
+		case r == 45: // ['-','-']
 			return 12
-		case r == 47: // ['/','/']
+		case r == 46: // ['.','.']
 			return 13
-		case r == 48: // ['0','0']
+		case r == 47: // ['/','/']
 			return 14
-		case 49 <= r && r <= 57: // ['1','9']
+		case r == 48: // ['0','0']
 			return 15
-		case r == 58: // [':',':']
+		case 49 <= r && r <= 57: // ['1','9']
 			return 16
-		case r == 59: // [';',';']
+		case r == 58: // [':',':']
 			return 17
-		case r == 60: // ['<','<']
+		case r == 59: // [';',';']
 			return 18
-		case r == 61: // ['=','=']
+		case r == 60: // ['<','<']
 			return 19
-		case r == 62: // ['>','>']
+		case r == 61: // ['=','=']
 			return 20
-		case 65 <= r && r <= 90: // ['A','Z']
+		case r == 62: // ['>','>']
 			return 21
-		case r == 91: // ['[','[']
+		case r == 63: // ['?','?']
 			return 22
-		case r == 93: // [']',']']
+		case 65 <= r && r <= 90: // ['A','Z']
 			return 23
-		case r == 94: // ['^','^']
+		case r == 91: // ['[','[']
 			return 24
-		case r == 95: // ['_','_']
+		case r == 93: // [']',']']
 			return 25
-		case r == 96: // ['`','`']
+		case r == 94: // ['^','^']
 			return 26
+		case r == 95: // ['_','_']
+			return 27
+		case r == 96: // ['`','`']
+			return 28
 		case 97 <= r && r <= 100: // ['a','d']
-			return 21
+			return 23
 		case r == 101: // ['e','e']
-			return 27
+			return 29
 		case r == 102: // ['f','f']
-			return 28
+			return 30
 		case 103 <= r && r <= 104: // ['g','h']
-			return 21
+			return 23
 		case r == 105: // ['i','i']
-			return 29
+			return 31
 		case 106 <= r && r <= 109: // ['j','m']
-			return 21
+			return 23
 		case r == 110: // ['n','n']
-			return 30
+			return 32
 		case 111 <= r && r <= 113: // ['o','q']
-			return 21
+			return 23
 		case r == 114: // ['r','r']
-			return 31
+			return 33
 		case r == 115: // ['s','s']
-			return 21
+			return 34
 		case r == 116: // ['t','t']
-			return 32
-		case 117 <= r && r <= 122: // ['u','z']
-			return 21
+			return 35
+		case 117 <= r && r <= 120: // ['u','x']
+			return 23
+		case r == 121: // ['y','y']
+			return 36
+		case r == 122: // ['z','z']
+			return 23
 		case r == 123: // ['{','{']
-			return 33
+			return 37
 		case r == 124: // ['|','|']
-			return 34
+			return 38
 		case r == 125: // ['}','}']
-			return 35
+			return 39
 		case r == 126: // ['~','~']
-			return 36
+			return 40
 		}
 		return NoState
 	},
@@ -116,7 +124,7 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 61: // ['=','=']
-			return 37
+			return 41
 		}
 		return NoState
 	},
@@ -124,9 +132,9 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case r == 34: // ['"','"']
-			return 38
+			return 42
 		case r == 92: // ['\','\']
-			return 39
+			return 43
 		default:
 			return 3
 		}
@@ -134,20 +142,27 @@ var TransTab = TransitionTable{
 	// S4
 	func(r rune) int {
 		switch {
+		case r == 10: // ['\n','\n']
+			return 44
+		default:
+			return 4
 		}
-		return NoState
 	},
 	// S5
 	func(r rune) int {
 		switch {
-		case r == 38: // ['&','&']
-			return 40
+		case r == 61: // ['=','=']
+			return 45
 		}
 		return NoState
 	},
 	// S6
 	func(r rune) int {
 		switch {
+		case r == 38: // ['&','&']
+			return 46
+		case r == 61: // ['=','=']
+			return 47
 		}
 		return NoState
 	},
@@ -166,12 +181,20 @@ var TransTab = TransitionTable{
 	// S9
 	func(r rune) int {
 		switch {
+		case r == 42: // ['*','*']
+			return 48
+		case r == 61: // ['=','=']
+			return 49
 		}
 		return NoState
 	},
 	// S10
 	func(r rune) int {
 		switch {
+		case r == 43: // ['+','+']
+			return 50
+		case r == 61: // ['=','=']
+			return 51
 		}
 		return NoState
 	},
@@ -184,285 +207,327 @@ var TransTab = TransitionTable{
 	// S12
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 41
+		case r == 45: // ['-','-']
+			return 52
+		case r == 61: // ['=','=']
+			return 53
 		}
 		return NoState
 	},
 	// S13
+	func(r rune) int {
+		switch {
+		case r == 46: // ['.','.']
+			return 54
+		case 48 <= r && r <= 57: // ['0','9']
+			return 55
+		}
+		return NoState
+	},
+	// S14
 	func(r rune) int {
 		switch {
 		case r == 42: // ['*','*']
-			return 42
+			return 56
 		case r == 47: // ['/','/']
-			return 43
+			return 57
+		case r == 61: // ['=','=']
+			return 58
 		}
 		return NoState
 	},
-	// S14
+	// S15
 	func(r rune) int {
 		switch {
 		case r == 46: // ['.','.']
-			return 44
+			return 59
 		case 48 <= r && r <= 55: // ['0','7']
-			return 45
+			return 60
 		case 56 <= r && r <= 57: // ['8','9']
-			return 46
+			return 61
 		case r == 69: // ['E','E']
-			return 47
+			return 62
 		case r == 88: // ['X','X']
-			return 48
+			return 63
 		case r == 101: // ['e','e']
-			return 47
+			return 62
 		case r == 120: // ['x','x']
-			return 48
+			return 63
 		}
 		return NoState
 	},
-	// S15
+	// S16
 	func(r rune) int {
 		switch {
 		case r == 46: // ['.','.']
-			return 44
+			return 59
 		case 48 <= r && r <= 57: // ['0','9']
-			return 15
+			return 16
 		case r == 69: // ['E','E']
-			return 47
+			return 62
 		case r == 101: // ['e','e']
-			return 47
+			return 62
 		}
 		return NoState
 	},
-	// S16
+	// S17
 	func(r rune) int {
 		switch {
 		}
 		return NoState
 	},
-	// S17
+	// S18
 	func(r rune) int {
 		switch {
 		}
 		return NoState
 	},
-	// S18
+	// S19
 	func(r rune) int {
 		switch {
 		case r == 60: // ['<','<']
-			return 49
+			return 64
 		case r == 61: // ['=','=']
-			return 50
+			return 65
 		}
 		return NoState
 	},
-	// S19
+	// S20
 	func(r rune) int {
 		switch {
 		case r == 61: // ['=','=']
-			return 51
+			return 66
 		}
 		return NoState
 	},
-	// S20
+	// S21
 	func(r rune) int {
 		switch {
 		case r == 61: // ['=','=']
-			return 52
+			return 67
 		case r == 62: // ['>','>']
-			return 53
+			return 68
 		}
 		return NoState
 	},
-	// S21
+	// S22
+	func(r rune) int {
+		switch {
+		case r == 46: // ['.','.']
+			return 69
+		case r == 63: // ['?','?']
+			return 70
+		}
+		return NoState
+	},
+	// S23
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
 		case 97 <= r && r <= 122: // ['a','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S22
+	// S24
 	func(r rune) int {
 		switch {
 		}
 		return NoState
 	},
-	// S23
+	// S25
 	func(r rune) int {
 		switch {
 		}
 		return NoState
 	},
-	// S24
+	// S26
 	func(r rune) int {
 		switch {
+		case r == 61: // ['=','=']
+			return 72
 		}
 		return NoState
 	},
-	// S25
+	// S27
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
 		case 97 <= r && r <= 122: // ['a','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S26
+	// S28
 	func(r rune) int {
 		switch {
 		case r == 96: // ['`','`']
-			return 55
+			return 73
 		default:
-			return 26
+			return 28
 		}
 	},
-	// S27
+	// S29
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
 		case 97 <= r && r <= 107: // ['a','k']
-			return 21
+			return 23
 		case r == 108: // ['l','l']
-			return 56
+			return 74
 		case 109 <= r && r <= 122: // ['m','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S28
+	// S30
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
 		case r == 97: // ['a','a']
-			return 57
+			return 75
 		case 98 <= r && r <= 109: // ['b','m']
-			return 21
+			return 23
 		case r == 110: // ['n','n']
-			return 58
+			return 76
 		case r == 111: // ['o','o']
-			return 59
+			return 77
 		case 112 <= r && r <= 122: // ['p','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S29
+	// S31
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
 		case 97 <= r && r <= 101: // ['a','e']
-			return 21
+			return 23
 		case r == 102: // ['f','f']
-			return 60
-		case 103 <= r && r <= 122: // ['g','z']
-			return 21
+			return 78
+		case 103 <= r && r <= 109: // ['g','m']
+			return 23
+		case r == 110: // ['n','n']
+			return 79
+		case 111 <= r && r <= 122: // ['o','z']
+			return 23
 		}
 		return NoState
 	},
-	// S30
+	// S32
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 116: // ['a','t']
-			return 21
+			return 27
+		case r == 97: // ['a','a']
+			return 80
+		case 98 <= r && r <= 116: // ['b','t']
+			return 23
 		case r == 117: // ['u','u']
-			return 61
+			return 81
 		case 118 <= r && r <= 122: // ['v','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S31
+	// S33
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
 		case 97 <= r && r <= 100: // ['a','d']
-			return 21
+			return 23
 		case r == 101: // ['e','e']
-			return 62
+			return 82
 		case 102 <= r && r <= 122: // ['f','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S32
+	// S34
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 113: // ['a','q']
-			return 21
-		case r == 114: // ['r','r']
-			return 63
-		case 115 <= r && r <= 122: // ['s','z']
-			return 21
-		}
-		return NoState
-	},
-	// S33
-	func(r rune) int {
-		switch {
-		}
-		return NoState
-	},
-	// S34
-	func(r rune) int {
-		switch {
-		case r == 124: // ['|','|']
-			return 64
+			return 27
+		case 97 <= r && r <= 115: // ['a','s']
+			return 23
+		case r == 116: // ['t','t']
+			return 83
+		case 117 <= r && r <= 122: // ['u','z']
+			return 23
 		}
 		return NoState
 	},
 	// S35
 	func(r rune) int {
 		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 113: // ['a','q']
+			return 23
+		case r == 114: // ['r','r']
+			return 84
+		case 115 <= r && r <= 122: // ['s','z']
+			return 23
 		}
 		return NoState
 	},
 	// S36
 	func(r rune) int {
 		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 104: // ['a','h']
+			return 23
+		case r == 105: // ['i','i']
+			return 85
+		case 106 <= r && r <= 122: // ['j','z']
+			return 23
 		}
 		return NoState
 	},
@@ -475,20 +540,16 @@ var TransTab = TransitionTable{
 	// S38
 	func(r rune) int {
 		switch {
+		case r == 61: // ['=','=']
+			return 86
+		case r == 124: // ['|','|']
+			return 87
 		}
 		return NoState
 	},
 	// S39
 	func(r rune) int {
 		switch {
-		case r == 34: // ['"','"']
-			return 3
-		case r == 110: // ['n','n']
-			return 65
-		case r == 114: // ['r','r']
-			return 65
-		case r == 116: // ['t','t']
-			return 65
 		}
 		return NoState
 	},
@@ -501,96 +562,58 @@ var TransTab = TransitionTable{
 	// S41
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 41
-		case r == 69: // ['E','E']
-			return 66
-		case r == 101: // ['e','e']
-			return 66
 		}
 		return NoState
 	},
 	// S42
 	func(r rune) int {
 		switch {
-		case r == 42: // ['*','*']
-			return 67
-		default:
-			return 42
 		}
+		return NoState
 	},
 	// S43
 	func(r rune) int {
 		switch {
-		case r == 10: // ['\n','\n']
-			return 68
-		default:
-			return 43
+		case r == 34: // ['"','"']
+			return 3
+		case r == 110: // ['n','n']
+			return 88
+		case r == 114: // ['r','r']
+			return 88
+		case r == 116: // ['t','t']
+			return 88
 		}
+		return NoState
 	},
 	// S44
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 69
-		case r == 69: // ['E','E']
-			return 70
-		case r == 101: // ['e','e']
-			return 70
 		}
 		return NoState
 	},
 	// S45
 	func(r rune) int {
 		switch {
-		case r == 46: // ['.','.']
-			return 44
-		case 48 <= r && r <= 55: // ['0','7']
-			return 45
-		case 56 <= r && r <= 57: // ['8','9']
-			return 46
-		case r == 69: // ['E','E']
-			return 47
-		case r == 101: // ['e','e']
-			return 47
 		}
 		return NoState
 	},
 	// S46
 	func(r rune) int {
 		switch {
-		case r == 46: // ['.','.']
-			return 44
-		case 48 <= r && r <= 57: // ['0','9']
-			return 46
-		case r == 69: // ['E','E']
-			return 47
-		case r == 101: // ['e','e']
-			return 47
 		}
 		return NoState
 	},
 	// S47
 	func(r rune) int {
 		switch {
-		case r == 43: // ['+','+']
-			return 71
-		case r == 45: // ['-','-']
-			return 71
-		case 48 <= r && r <= 57: // ['0','9']
-			return 72
 		}
 		return NoState
 	},
 	// S48
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 73
-		case 65 <= r && r <= 70: // ['A','F']
-			return 74
-		case 97 <= r && r <= 102: // ['a','f']
-			return 74
+		case r == 61: // ['=','=']
+			return 89
 		}
 		return NoState
 	},
@@ -627,70 +650,43 @@ var TransTab = TransitionTable{
 	// S54
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 54
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
-		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 122: // ['a','z']
-			return 21
+		case r == 46: // ['.','.']
+			return 90
 		}
 		return NoState
 	},
 	// S55
 	func(r rune) int {
 		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 55
+		case r == 69: // ['E','E']
+			return 91
+		case r == 101: // ['e','e']
+			return 91
 		}
 		return NoState
 	},
 	// S56
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 54
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
-		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 114: // ['a','r']
-			return 21
-		case r == 115: // ['s','s']
-			return 75
-		case 116 <= r && r <= 122: // ['t','z']
-			return 21
+		case r == 42: // ['*','*']
+			return 92
+		default:
+			return 56
 		}
-		return NoState
 	},
 	// S57
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 54
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
-		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 107: // ['a','k']
-			return 21
-		case r == 108: // ['l','l']
-			return 76
-		case 109 <= r && r <= 122: // ['m','z']
-			return 21
+		case r == 61: // ['=','=']
+			return 93
 		}
 		return NoState
 	},
 	// S58
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 54
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
-		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 122: // ['a','z']
-			return 21
 		}
 		return NoState
 	},
@@ -698,67 +694,53 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
-		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 113: // ['a','q']
-			return 21
-		case r == 114: // ['r','r']
-			return 77
-		case 115 <= r && r <= 122: // ['s','z']
-			return 21
+			return 94
+		case r == 69: // ['E','E']
+			return 95
+		case r == 101: // ['e','e']
+			return 95
 		}
 		return NoState
 	},
 	// S60
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 54
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
-		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 122: // ['a','z']
-			return 21
-		}
-		return NoState
-	},
-	// S61
+		case r == 46: // ['.','.']
+			return 59
+		case 48 <= r && r <= 55: // ['0','7']
+			return 60
+		case 56 <= r && r <= 57: // ['8','9']
+			return 61
+		case r == 69: // ['E','E']
+			return 62
+		case r == 101: // ['e','e']
+			return 62
+		}
+		return NoState
+	},
+	// S61
 	func(r rune) int {
 		switch {
+		case r == 46: // ['.','.']
+			return 59
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
-		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 107: // ['a','k']
-			return 21
-		case r == 108: // ['l','l']
-			return 78
-		case 109 <= r && r <= 122: // ['m','z']
-			return 21
+			return 61
+		case r == 69: // ['E','E']
+			return 62
+		case r == 101: // ['e','e']
+			return 62
 		}
 		return NoState
 	},
 	// S62
 	func(r rune) int {
 		switch {
+		case r == 43: // ['+','+']
+			return 96
+		case r == 45: // ['-','-']
+			return 96
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
-		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 115: // ['a','s']
-			return 21
-		case r == 116: // ['t','t']
-			return 79
-		case 117 <= r && r <= 122: // ['u','z']
-			return 21
+			return 97
 		}
 		return NoState
 	},
@@ -766,87 +748,59 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
-		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
-		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 116: // ['a','t']
-			return 21
-		case r == 117: // ['u','u']
-			return 80
-		case 118 <= r && r <= 122: // ['v','z']
-			return 21
+			return 98
+		case 65 <= r && r <= 70: // ['A','F']
+			return 99
+		case 97 <= r && r <= 102: // ['a','f']
+			return 99
 		}
 		return NoState
 	},
 	// S64
 	func(r rune) int {
 		switch {
+		case r == 61: // ['=','=']
+			return 100
 		}
 		return NoState
 	},
 	// S65
 	func(r rune) int {
 		switch {
-		case r == 34: // ['"','"']
-			return 38
-		case r == 92: // ['\','\']
-			return 39
-		default:
-			return 3
 		}
+		return NoState
 	},
 	// S66
 	func(r rune) int {
 		switch {
-		case r == 43: // ['+','+']
-			return 81
-		case r == 45: // ['-','-']
-			return 81
-		case 48 <= r && r <= 57: // ['0','9']
-			return 82
 		}
 		return NoState
 	},
 	// S67
 	func(r rune) int {
 		switch {
-		case r == 42: // ['*','*']
-			return 67
-		case r == 47: // ['/','/']
-			return 83
-		default:
-			return 42
 		}
+		return NoState
 	},
 	// S68
 	func(r rune) int {
 		switch {
+		case r == 61: // ['=','=']
+			return 101
 		}
 		return NoState
 	},
 	// S69
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 69
-		case r == 69: // ['E','E']
-			return 70
-		case r == 101: // ['e','e']
-			return 70
 		}
 		return NoState
 	},
 	// S70
 	func(r rune) int {
 		switch {
-		case r == 43: // ['+','+']
-			return 84
-		case r == 45: // ['-','-']
-			return 84
-		case 48 <= r && r <= 57: // ['0','9']
-			return 85
+		case r == 61: // ['=','=']
+			return 102
 		}
 		return NoState
 	},
@@ -854,27 +808,25 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 72
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 122: // ['a','z']
+			return 23
 		}
 		return NoState
 	},
 	// S72
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 72
 		}
 		return NoState
 	},
 	// S73
 	func(r rune) int {
 		switch {
-		case 48 <= r && r <= 57: // ['0','9']
-			return 73
-		case 65 <= r && r <= 70: // ['A','F']
-			return 74
-		case 97 <= r && r <= 102: // ['a','f']
-			return 74
 		}
 		return NoState
 	},
@@ -882,11 +834,17 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 73
-		case 65 <= r && r <= 70: // ['A','F']
-			return 74
-		case 97 <= r && r <= 102: // ['a','f']
-			return 74
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 114: // ['a','r']
+			return 23
+		case r == 115: // ['s','s']
+			return 103
+		case 116 <= r && r <= 122: // ['t','z']
+			return 23
 		}
 		return NoState
 	},
@@ -894,17 +852,17 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 100: // ['a','d']
-			return 21
-		case r == 101: // ['e','e']
-			return 86
-		case 102 <= r && r <= 122: // ['f','z']
-			return 21
+			return 27
+		case 97 <= r && r <= 107: // ['a','k']
+			return 23
+		case r == 108: // ['l','l']
+			return 104
+		case 109 <= r && r <= 122: // ['m','z']
+			return 23
 		}
 		return NoState
 	},
@@ -912,17 +870,13 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 114: // ['a','r']
-			return 21
-		case r == 115: // ['s','s']
-			return 87
-		case 116 <= r && r <= 122: // ['t','z']
-			return 21
+			return 27
+		case 97 <= r && r <= 122: // ['a','z']
+			return 23
 		}
 		return NoState
 	},
@@ -930,13 +884,17 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 122: // ['a','z']
-			return 21
+			return 27
+		case 97 <= r && r <= 113: // ['a','q']
+			return 23
+		case r == 114: // ['r','r']
+			return 105
+		case 115 <= r && r <= 122: // ['s','z']
+			return 23
 		}
 		return NoState
 	},
@@ -944,17 +902,13 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 107: // ['a','k']
-			return 21
-		case r == 108: // ['l','l']
-			return 88
-		case 109 <= r && r <= 122: // ['m','z']
-			return 21
+			return 27
+		case 97 <= r && r <= 122: // ['a','z']
+			return 23
 		}
 		return NoState
 	},
@@ -962,17 +916,17 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 116: // ['a','t']
-			return 21
-		case r == 117: // ['u','u']
-			return 89
-		case 118 <= r && r <= 122: // ['v','z']
-			return 21
+			return 27
+		case 97 <= r && r <= 101: // ['a','e']
+			return 23
+		case r == 102: // ['f','f']
+			return 106
+		case 103 <= r && r <= 122: // ['g','z']
+			return 23
 		}
 		return NoState
 	},
@@ -980,17 +934,17 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
-		case 97 <= r && r <= 100: // ['a','d']
-			return 21
-		case r == 101: // ['e','e']
-			return 90
-		case 102 <= r && r <= 122: // ['f','z']
-			return 21
+			return 27
+		case 97 <= r && r <= 109: // ['a','m']
+			return 23
+		case r == 110: // ['n','n']
+			return 107
+		case 111 <= r && r <= 122: // ['o','z']
+			return 23
 		}
 		return NoState
 	},
@@ -998,7 +952,17 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 82
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 107: // ['a','k']
+			return 23
+		case r == 108: // ['l','l']
+			return 108
+		case 109 <= r && r <= 122: // ['m','z']
+			return 23
 		}
 		return NoState
 	},
@@ -1006,13 +970,35 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 82
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 115: // ['a','s']
+			return 23
+		case r == 116: // ['t','t']
+			return 109
+		case 117 <= r && r <= 122: // ['u','z']
+			return 23
 		}
 		return NoState
 	},
 	// S83
 	func(r rune) int {
 		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 113: // ['a','q']
+			return 23
+		case r == 114: // ['r','r']
+			return 110
+		case 115 <= r && r <= 122: // ['s','z']
+			return 23
 		}
 		return NoState
 	},
@@ -1020,125 +1006,579 @@ var TransTab = TransitionTable{
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 85
-		}
-		return NoState
-	},
-	// S85
-	func(r rune) int {
-		switch {
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 116: // ['a','t']
+			return 23
+		case r == 117: // ['u','u']
+			return 111
+		case 118 <= r && r <= 122: // ['v','z']
+			return 23
+		}
+		return NoState
+	},
+	// S85
+	func(r rune) int {
+		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 85
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 100: // ['a','d']
+			return 23
+		case r == 101: // ['e','e']
+			return 112
+		case 102 <= r && r <= 122: // ['f','z']
+			return 23
 		}
 		return NoState
 	},
 	// S86
 	func(r rune) int {
 		switch {
+		}
+		return NoState
+	},
+	// S87
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S88
+	func(r rune) int {
+		switch {
+		case r == 34: // ['"','"']
+			return 42
+		case r == 92: // ['\','\']
+			return 43
+		default:
+			return 3
+		}
+	},
+	// S89
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S90
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S91
+	func(r rune) int {
+		switch {
+		case r == 43: // ['+','+']
+			return 113
+		case r == 45: // ['-','-']
+			return 113
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 114
+		}
+		return NoState
+	},
+	// S92
+	func(r rune) int {
+		switch {
+		case r == 42: // ['*','*']
+			return 92
+		case r == 47: // ['/','/']
+			return 115
+		default:
+			return 56
+		}
+	},
+	// S93
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S94
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 94
+		case r == 69: // ['E','E']
+			return 95
+		case r == 101: // ['e','e']
+			return 95
+		}
+		return NoState
+	},
+	// S95
+	func(r rune) int {
+		switch {
+		case r == 43: // ['+','+']
+			return 116
+		case r == 45: // ['-','-']
+			return 116
+		case 48 <= r && r <= 57: // ['0','9']
+			return 117
+		}
+		return NoState
+	},
+	// S96
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 97
+		}
+		return NoState
+	},
+	// S97
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 97
+		}
+		return NoState
+	},
+	// S98
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 98
+		case 65 <= r && r <= 70: // ['A','F']
+			return 99
+		case 97 <= r && r <= 102: // ['a','f']
+			return 99
+		}
+		return NoState
+	},
+	// S99
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 98
+		case 65 <= r && r <= 70: // ['A','F']
+			return 99
+		case 97 <= r && r <= 102: // ['a','f']
+			return 99
+		}
+		return NoState
+	},
+	// S100
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S101
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S102
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S103
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
+		case 97 <= r && r <= 100: // ['a','d']
+			return 23
+		case r == 101: // ['e','e']
+			return 118
+		case 102 <= r && r <= 122: // ['f','z']
+			return 23
+		}
+		return NoState
+	},
+	// S104
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 114: // ['a','r']
+			return 23
+		case r == 115: // ['s','s']
+			return 119
+		case 116 <= r && r <= 122: // ['t','z']
+			return 23
+		}
+		return NoState
+	},
+	// S105
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
 		case 97 <= r && r <= 122: // ['a','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S87
+	// S106
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
+		case 97 <= r && r <= 122: // ['a','z']
+			return 23
+		}
+		return NoState
+	},
+	// S107
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 122: // ['a','z']
+			return 23
+		}
+		return NoState
+	},
+	// S108
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 107: // ['a','k']
+			return 23
+		case r == 108: // ['l','l']
+			return 120
+		case 109 <= r && r <= 122: // ['m','z']
+			return 23
+		}
+		return NoState
+	},
+	// S109
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 116: // ['a','t']
+			return 23
+		case r == 117: // ['u','u']
+			return 121
+		case 118 <= r && r <= 122: // ['v','z']
+			return 23
+		}
+		return NoState
+	},
+	// S110
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 116: // ['a','t']
+			return 23
+		case r == 117: // ['u','u']
+			return 122
+		case 118 <= r && r <= 122: // ['v','z']
+			return 23
+		}
+		return NoState
+	},
+	// S111
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
 		case 97 <= r && r <= 100: // ['a','d']
-			return 21
+			return 23
 		case r == 101: // ['e','e']
-			return 90
+			return 123
 		case 102 <= r && r <= 122: // ['f','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S88
+	// S112
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
+		case 97 <= r && r <= 107: // ['a','k']
+			return 23
+		case r == 108: // ['l','l']
+			return 124
+		case 109 <= r && r <= 122: // ['m','z']
+			return 23
+		}
+		return NoState
+	},
+	// S113
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 114
+		}
+		return NoState
+	},
+	// S114
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 114
+		}
+		return NoState
+	},
+	// S115
+	func(r rune) int {
+		switch {
+		}
+		return NoState
+	},
+	// S116
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 117
+		}
+		return NoState
+	},
+	// S117
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 117
+		}
+		return NoState
+	},
+	// S118
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
 		case 97 <= r && r <= 122: // ['a','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S89
+	// S119
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
+		case 97 <= r && r <= 100: // ['a','d']
+			return 23
+		case r == 101: // ['e','e']
+			return 123
+		case 102 <= r && r <= 122: // ['f','z']
+			return 23
+		}
+		return NoState
+	},
+	// S120
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 122: // ['a','z']
+			return 23
+		}
+		return NoState
+	},
+	// S121
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
 		case 97 <= r && r <= 113: // ['a','q']
-			return 21
+			return 23
 		case r == 114: // ['r','r']
-			return 91
+			return 125
 		case 115 <= r && r <= 122: // ['s','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S90
+	// S122
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
+		case 97 <= r && r <= 98: // ['a','b']
+			return 23
+		case r == 99: // ['c','c']
+			return 126
+		case 100 <= r && r <= 122: // ['d','z']
+			return 23
+		}
+		return NoState
+	},
+	// S123
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
 		case 97 <= r && r <= 122: // ['a','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S91
+	// S124
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
+		case 97 <= r && r <= 99: // ['a','c']
+			return 23
+		case r == 100: // ['d','d']
+			return 127
+		case 101 <= r && r <= 122: // ['e','z']
+			return 23
+		}
+		return NoState
+	},
+	// S125
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
 		case 97 <= r && r <= 109: // ['a','m']
-			return 21
+			return 23
 		case r == 110: // ['n','n']
-			return 92
+			return 128
 		case 111 <= r && r <= 122: // ['o','z']
-			return 21
+			return 23
 		}
 		return NoState
 	},
-	// S92
+	// S126
 	func(r rune) int {
 		switch {
 		case 48 <= r && r <= 57: // ['0','9']
-			return 54
+			return 71
 		case 65 <= r && r <= 90: // ['A','Z']
-			return 21
+			return 23
 		case r == 95: // ['_','_']
-			return 25
+			return 27
+		case 97 <= r && r <= 115: // ['a','s']
+			return 23
+		case r == 116: // ['t','t']
+			return 129
+		case 117 <= r && r <= 122: // ['u','z']
+			return 23
+		}
+		return NoState
+	},
+	// S127
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
 		case 97 <= r && r <= 122: // ['a','z']
-			return 21
+			return 23
+		}
+		return NoState
+	},
+	// S128
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 122: // ['a','z']
+			return 23
+		}
+		return NoState
+	},
+	// S129
+	func(r rune) int {
+		switch {
+		case 48 <= r && r <= 57: // ['0','9']
+			return 71
+		case 65 <= r && r <= 90: // ['A','Z']
+			return 23
+		case r == 95: // ['_','_']
+			return 27
+		case 97 <= r && r <= 122: // ['a','z']
+			return 23
 		}
 		return NoState
 	},
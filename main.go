@@ -7,13 +7,30 @@ import (
 	"os"
 	"os/user"
 	"path"
+	"strings"
 
 	"github.com/Ars2014/ulang/repl"
 )
 
+// pathList collects the values of a flag which may be repeated.
+type pathList []string
+
+func (l *pathList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *pathList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
 var (
 	interactive bool
 	version     bool
+	roots       pathList
+	seed        int64
+	allowProc   bool
+	strictIndex bool
 )
 
 func init() {
@@ -25,6 +42,10 @@ func init() {
 
 	flag.BoolVar(&version, "v", false, "display version information")
 	flag.BoolVar(&interactive, "i", false, "enable interactive mode")
+	flag.BoolVar(&allowProc, "allow-process", false, "allow access to environment variables, the working directory and commands")
+	flag.BoolVar(&strictIndex, "strict-index", false, "make reading an index out of range an error instead of null")
+	flag.Int64Var(&seed, "seed", 0, "seed the random number generator to make runs reproducible")
+	flag.Var(&roots, "root", "allow file access below `dir` (repeatable, defaults to the working directory)")
 }
 
 func main() {
@@ -42,10 +63,26 @@ func main() {
 
 	args := flag.Args()
 
+	if len(roots) == 0 {
+		wd, err := os.Getwd()
+		if err != nil {
+			log.Fatalf("could not determine working directory: %s", err)
+		}
+		roots = pathList{wd}
+	}
+
 	opts := &repl.Options{
-		Debug:       false,
-		Interactive: interactive,
+		Debug:        false,
+		Interactive:  interactive,
+		Roots:        roots,
+		AllowProcess: allowProc,
+		StrictIndex:  strictIndex,
 	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			opts.Seed = &seed
+		}
+	})
 	repl_ := repl.New(currUser.Username, args, opts)
 	repl_.Run()
 }
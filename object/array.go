@@ -75,8 +75,8 @@ func (a *Array) Compare(other Object) int {
 			if !ok {
 				return -1
 			}
-			if cmp.Compare(obj.Elements[i]) != 0 {
-				return cmp.Compare(obj.Elements[i])
+			if c := cmp.Compare(obj.Elements[i]); c != 0 {
+				return c
 			}
 		}
 
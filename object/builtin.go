@@ -2,6 +2,14 @@ package object
 
 import "fmt"
 
+// Apply calls the function fn with args on behalf of a builtin called from
+// env. It is provided by the evaluator.
+var Apply func(env *Environment, fn Object, args []Object) Object
+
+// ApplyOperator evaluates the binary operator on left and right on behalf of
+// a builtin called from env. It is provided by the evaluator.
+var ApplyOperator func(env *Environment, operator string, left, right Object) Object
+
 type Builtin struct {
 	Name string
 	Fn   BuiltinFunction
@@ -22,3 +30,26 @@ func (b *Builtin) Inspect() string {
 func (b *Builtin) Type() Type {
 	return BuiltInType
 }
+
+// BuiltinMethod is a builtin selected from a value of a builtin type, such
+// as s.upper, which is called with the value as its first argument.
+type BuiltinMethod struct {
+	Self    Object
+	Builtin *Builtin
+}
+
+func (b *BuiltinMethod) Bool() bool {
+	return true
+}
+
+func (b *BuiltinMethod) String() string {
+	return b.Inspect()
+}
+
+func (b *BuiltinMethod) Inspect() string {
+	return fmt.Sprintf("<built-in method %s of %s>", b.Builtin.Name, b.Self.Type())
+}
+
+func (b *BuiltinMethod) Type() Type {
+	return MethodType
+}
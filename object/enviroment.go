@@ -1,34 +1,146 @@
 package object
 
-import "unicode"
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// interpreter holds the state shared by all environments of one interpreter.
+type interpreter struct {
+	mu        sync.Mutex
+	random    *rand.Rand
+	exitHooks []Object
+	modules   map[string]*Hash
+
+	// Once a task has been spawned, a goroutine must hold gil while it
+	// evaluates, so that objects are never accessed concurrently. Blocking
+	// operations release it for other tasks to run.
+	gil      sync.Mutex
+	threaded bool // guarded by mu
+
+	// current is the state of the task holding gil. Each goroutine which
+	// takes gil switches it to its own task.
+	current *task
+}
+
+// task holds the state of one task of the interpreter, which the goroutine
+// running it must not share with others.
+type task struct {
+	// frame is the frame of the innermost function call being evaluated,
+	// and raised catches the errors of protocol methods called from
+	// outside of the evaluator.
+	frame  *Environment
+	raised **Error
+}
 
 type Environment struct {
 	store  map[string]Object
 	parent *Environment
+	depth  int
+	interp *interpreter
+	yield  func(Object) bool // set in the frame of a generator
 }
 
+// NewEnvironment returns the global environment of a new interpreter.
 func NewEnvironment() *Environment {
 	s := make(map[string]Object)
-	return &Environment{store: s}
+	return &Environment{store: s, interp: &interpreter{current: &task{}}}
 }
 
 func (e *Environment) ExportedHash() *Hash {
-	pairs := make(map[HashKey]HashPair)
-	for k, v := range e.store {
+	var names []string
+	for k := range e.store {
 		if unicode.IsUpper(rune(k[0])) {
-			s := &String{Value: k}
-			pairs[s.HashKey()] = HashPair{Key: s, Value: v}
+			names = append(names, k)
 		}
 	}
-	return &Hash{Pairs: pairs}
+	sort.Strings(names)
+
+	hash := NewHash()
+	for _, name := range names {
+		hash.Set(&String{Value: name}, e.store[name])
+	}
+	return hash
 }
 
 func (e *Environment) NewChild() *Environment {
-	env := NewEnvironment()
-	env.parent = e
+	s := make(map[string]Object)
+	return &Environment{store: s, parent: e, depth: e.depth, interp: e.interp}
+}
+
+// NewFrame returns a child environment for a function call made from caller.
+func (e *Environment) NewFrame(caller *Environment) *Environment {
+	env := e.NewChild()
+	env.depth = caller.depth + 1
 	return env
 }
 
+// Activate records that the interpreter evaluates the frame e and returns
+// the frame it evaluated before, which is passed to Restore on return.
+func (e *Environment) Activate() *Environment {
+	t := e.interp.current
+	prev := t.frame
+	t.frame = e
+	return prev
+}
+
+// Restore makes frame the frame the interpreter evaluates again.
+func (e *Environment) Restore(frame *Environment) {
+	e.interp.current.frame = frame
+}
+
+// Frame returns the frame the interpreter evaluates, or e outside of any
+// function call. Protocol methods called from outside of the evaluator,
+// such as __eq__ by Compare, are called from it so that they count towards
+// the depth of the call which led to them.
+func (e *Environment) Frame() *Environment {
+	if frame := e.interp.current.frame; frame != nil {
+		return frame
+	}
+	return e
+}
+
+// Catch calls fn and returns the first error passed to Raise meanwhile.
+func (e *Environment) Catch(fn func()) *Error {
+	var err *Error
+	t := e.interp.current
+	prev := t.raised
+	t.raised = &err
+	defer func() { t.raised = prev }()
+	fn()
+	return err
+}
+
+// Raise reports an error of a protocol method called from outside of the
+// evaluator, where it cannot be returned, to the innermost Catch. Without
+// one the error is dropped.
+func (e *Environment) Raise(err *Error) {
+	if t := e.interp.current; t.raised != nil && *t.raised == nil {
+		*t.raised = err
+	}
+}
+
+// SetYield makes the environment the frame of a generator, which passes
+// the values it yields to fn. fn reports whether the generator is resumed
+// rather than closed.
+func (e *Environment) SetYield(fn func(Object) bool) {
+	e.yield = fn
+}
+
+// Yield returns the function set by SetYield, which is nil unless the
+// environment is the frame of a generator.
+func (e *Environment) Yield() func(Object) bool {
+	return e.yield
+}
+
+// Depth reports how many nested function calls the environment belongs to.
+func (e *Environment) Depth() int {
+	return e.depth
+}
+
 func (e *Environment) Get(name string) (Object, bool) {
 	obj, ok := e.store[name]
 	if !ok && e.parent != nil {
@@ -41,3 +153,135 @@ func (e *Environment) Set(name string, val Object) Object {
 	e.store[name] = val
 	return val
 }
+
+// Spawn runs fn on a new goroutine as a task of the interpreter. The task
+// only runs while all others are blocked in calls of Block.
+func (e *Environment) Spawn(fn func()) {
+	e.interp.mu.Lock()
+	if !e.interp.threaded {
+		// The caller is the only goroutine evaluating, so it takes the
+		// lock it would otherwise have had to hold all along.
+		e.interp.threaded = true
+		e.interp.gil.Lock()
+	}
+	e.interp.mu.Unlock()
+
+	go func() {
+		e.interp.gil.Lock()
+		defer e.interp.gil.Unlock()
+		e.switchTask(&task{})
+		fn()
+	}()
+}
+
+// Block calls fn, which must not access objects of the interpreter, letting
+// other tasks run until it returns. It is used for operations which may
+// block, such as receiving from a channel or I/O.
+func (e *Environment) Block(fn func()) {
+	t := e.interp.current
+	defer e.switchTask(t)
+	if e.threaded() {
+		e.interp.gil.Unlock()
+		defer e.interp.gil.Lock()
+	}
+	fn()
+}
+
+// Enter calls fn, which may evaluate, from a goroutine which is not a task
+// of the interpreter, such as one serving an HTTP request. Callers must not
+// enter concurrently before a task has been spawned.
+func (e *Environment) Enter(fn func()) {
+	if e.threaded() {
+		e.interp.gil.Lock()
+	}
+	defer func() {
+		// fn may have spawned the first task, which took the lock for it.
+		if e.threaded() {
+			e.interp.gil.Unlock()
+		}
+	}()
+	t := e.switchTask(&task{})
+	defer e.switchTask(t)
+	fn()
+}
+
+// switchTask makes t the task the interpreter evaluates and returns the one
+// it evaluated before. It takes mu as well, since goroutines entering before
+// a task has been spawned do not take gil.
+func (e *Environment) switchTask(t *task) *task {
+	e.interp.mu.Lock()
+	defer e.interp.mu.Unlock()
+	prev := e.interp.current
+	e.interp.current = t
+	return prev
+}
+
+func (e *Environment) threaded() bool {
+	e.interp.mu.Lock()
+	defer e.interp.mu.Unlock()
+	return e.interp.threaded
+}
+
+// Seed resets the random number generator of the interpreter.
+func (e *Environment) Seed(seed int64) {
+	e.interp.mu.Lock()
+	defer e.interp.mu.Unlock()
+	e.interp.random = rand.New(rand.NewSource(seed))
+}
+
+// Random calls fn with the random number generator of the interpreter, which
+// is seeded from the current time unless Seed was called.
+func (e *Environment) Random(fn func(r *rand.Rand)) {
+	e.interp.mu.Lock()
+	defer e.interp.mu.Unlock()
+	if e.interp.random == nil {
+		e.interp.random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	fn(e.interp.random)
+}
+
+// Module returns the interpreter's own copy of the module template, made
+// on first use, so that assigning to a module does not change it for other
+// interpreters.
+func (e *Environment) Module(name string, template *Hash) *Hash {
+	e.interp.mu.Lock()
+	defer e.interp.mu.Unlock()
+	module, ok := e.interp.modules[name]
+	if !ok {
+		if e.interp.modules == nil {
+			e.interp.modules = make(map[string]*Hash)
+		}
+		module = template.Copy()
+		e.interp.modules[name] = module
+	}
+	return module
+}
+
+// AtExit registers fn to be called by RunExitHooks.
+func (e *Environment) AtExit(fn Object) {
+	e.interp.mu.Lock()
+	defer e.interp.mu.Unlock()
+	e.interp.exitHooks = append(e.interp.exitHooks, fn)
+}
+
+// RunExitHooks calls the functions registered with AtExit in reverse order
+// of registration, each at most once. It returns the last error raised by a
+// hook, if any.
+func (e *Environment) RunExitHooks() *Error {
+	var err *Error
+	for {
+		e.interp.mu.Lock()
+		n := len(e.interp.exitHooks)
+		if n == 0 {
+			e.interp.mu.Unlock()
+			return err
+		}
+		fn := e.interp.exitHooks[n-1]
+		e.interp.exitHooks = e.interp.exitHooks[:n-1]
+		e.interp.mu.Unlock()
+
+		if result, ok := Apply(e, fn, nil).(*Error); ok {
+			err = result
+		}
+	}
+}
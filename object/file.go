@@ -0,0 +1,76 @@
+package object
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// File is an open file handle returned by the open() builtin.
+type File struct {
+	Name string
+	Mode string
+
+	handle *os.File
+	reader *bufio.Reader
+}
+
+func NewFile(name, mode string, handle *os.File) *File {
+	return &File{Name: name, Mode: mode, handle: handle, reader: bufio.NewReader(handle)}
+}
+
+func (f *File) Closed() bool {
+	return f.handle == nil
+}
+
+// ReadLine returns the next line without its line terminator. ok is false
+// once the end of file is reached.
+func (f *File) ReadLine() (line string, ok bool, err error) {
+	line, err = f.reader.ReadString('\n')
+	if err == io.EOF {
+		if line == "" {
+			return "", false, nil
+		}
+		err = nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	return line, true, nil
+}
+
+func (f *File) Write(s string) (int, error) {
+	return f.handle.WriteString(s)
+}
+
+func (f *File) Close() error {
+	err := f.handle.Close()
+	f.handle = nil
+	f.reader = nil
+	return err
+}
+
+func (f *File) Bool() bool {
+	return true
+}
+
+func (f *File) String() string {
+	return f.Inspect()
+}
+
+func (f *File) Inspect() string {
+	state := "open"
+	if f.Closed() {
+		state = "closed"
+	}
+	return fmt.Sprintf("<%s file %q mode %q>", state, f.Name, f.Mode)
+}
+
+func (f *File) Type() Type {
+	return FileType
+}
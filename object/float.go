@@ -2,6 +2,7 @@ package object
 
 import (
 	"math"
+	"math/big"
 	"strconv"
 )
 
@@ -13,9 +14,15 @@ func (f *Float) Bool() bool {
 	return !math.IsNaN(f.Value) && f.Value != 0.0
 }
 
+// Compare orders floats totally so that they can be sorted: NaN equals NaN
+// and is less than any other number. Comparison operators follow IEEE 754
+// instead and are implemented by the evaluator.
 func (f *Float) Compare(other Object) int {
 	switch obj := other.(type) {
 	case *Float:
+		if math.IsNaN(f.Value) || math.IsNaN(obj.Value) {
+			return compareNaN(f.Value, obj.Value)
+		}
 		switch {
 		case f.Value < obj.Value:
 			return -1
@@ -25,14 +32,7 @@ func (f *Float) Compare(other Object) int {
 			return 0
 		}
 	case *Integer:
-		switch {
-		case f.Value < float64(obj.Value):
-			return -1
-		case f.Value > float64(obj.Value):
-			return 1
-		default:
-			return 0
-		}
+		return f.compareInteger(obj)
 	}
 
 	return -1
@@ -43,7 +43,16 @@ func (f *Float) String() string {
 }
 
 func (f *Float) Inspect() string {
-	return strconv.FormatFloat(f.Value, 'f', -1, 64)
+	switch {
+	case math.IsNaN(f.Value):
+		return "nan"
+	case math.IsInf(f.Value, 1):
+		return "inf"
+	case math.IsInf(f.Value, -1):
+		return "-inf"
+	default:
+		return strconv.FormatFloat(f.Value, 'f', -1, 64)
+	}
 }
 
 func (f *Float) Type() Type {
@@ -54,6 +63,50 @@ func (f *Float) Clone() Object {
 	return &Float{Value: f.Value}
 }
 
+// HashKey returns the hash key of the equal integer for integral values so
+// that 1 and 1.0 are the same key.
 func (f *Float) HashKey() HashKey {
-	return HashKey{Type: f.Type(), Value: math.Float64bits(f.Value)}
+	switch {
+	case math.IsNaN(f.Value):
+		return HashKey{Type: f.Type(), Value: math.Float64bits(math.NaN())}
+	case math.IsInf(f.Value, 0) || f.Value != math.Trunc(f.Value):
+		return HashKey{Type: f.Type(), Value: math.Float64bits(f.Value)}
+	case math.Abs(f.Value) < 1<<63:
+		return (&Integer{Value: int64(f.Value)}).HashKey()
+	default:
+		i, _ := big.NewFloat(f.Value).Int(nil)
+		return NewBigInteger(i).HashKey()
+	}
+}
+
+func (f *Float) compareInteger(i *Integer) int {
+	if math.IsNaN(f.Value) {
+		return -1
+	}
+
+	// Integers beyond 2**53 are compared exactly rather than rounded to a
+	// float, so that equality agrees with HashKey.
+	if i.Big != nil || i.Value > 1<<53 || i.Value < -1<<53 {
+		return big.NewFloat(f.Value).Cmp(new(big.Float).SetInt(i.BigValue()))
+	}
+
+	switch iv := i.Float64(); {
+	case f.Value < iv:
+		return -1
+	case f.Value > iv:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareNaN(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return -1
+	default:
+		return 1
+	}
 }
@@ -7,9 +7,10 @@ import (
 )
 
 type Function struct {
-	Parameters ast.IdentifierList
+	Parameters ast.ExpressionList
 	Body       *ast.BlockStatement
 	Env        *Environment
+	Generator  bool // calling it returns a generator which runs the body
 }
 
 func (f *Function) Bool() bool {
@@ -40,6 +41,19 @@ func (f *Function) Type() Type {
 	return FunctionType
 }
 
+// IsCallable reports whether obj can be called like a function.
+func IsCallable(obj Object) bool {
+	switch obj := obj.(type) {
+	case *Function, *Builtin, *BoundMethod, *BuiltinMethod, *Struct:
+		return true
+	case *Instance:
+		_, ok := obj.Method("__call__")
+		return ok
+	default:
+		return false
+	}
+}
+
 type Return struct {
 	Value Object
 }
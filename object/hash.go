@@ -15,18 +15,138 @@ type HashPair struct {
 	Value Object
 }
 
+// Hash maps hashable keys to values and remembers the order in which keys
+// were inserted. Assigning to an existing key keeps its position. Keys with
+// the same HashKey are told apart by comparing them.
 type Hash struct {
-	Pairs map[HashKey]HashPair
+	index   map[HashKey][]int // positions in pairs of the keys with each hash
+	pairs   []HashPair        // deleted entries have a nil Key
+	deleted int
+}
+
+func NewHash() *Hash {
+	return &Hash{index: make(map[HashKey][]int)}
+}
+
+func keysEqual(a, b Object) bool {
+	if cmp, ok := a.(Comparable); ok {
+		return cmp.Compare(b) == 0
+	}
+	return a == b
+}
+
+// find returns the hash key of key and its position in pairs, or -1 when it
+// is missing.
+func (h *Hash) find(key Object) (HashKey, int) {
+	hashed := key.(Hashable).HashKey()
+	for _, i := range h.index[hashed] {
+		if keysEqual(h.pairs[i].Key, key) {
+			return hashed, i
+		}
+	}
+	return hashed, -1
+}
+
+// Get returns the value stored for key, which must be hashable.
+func (h *Hash) Get(key Object) (Object, bool) {
+	_, i := h.find(key)
+	if i < 0 {
+		return nil, false
+	}
+	return h.pairs[i].Value, true
+}
+
+// Set stores value for key, which must be hashable.
+func (h *Hash) Set(key, value Object) {
+	hashed, i := h.find(key)
+	if i >= 0 {
+		h.pairs[i].Value = value
+		return
+	}
+
+	if h.index == nil {
+		h.index = make(map[HashKey][]int)
+	}
+	h.index[hashed] = append(h.index[hashed], len(h.pairs))
+	h.pairs = append(h.pairs, HashPair{Key: key, Value: value})
+}
+
+// Delete removes key, which must be hashable, and reports whether it was
+// present.
+func (h *Hash) Delete(key Object) bool {
+	hashed, i := h.find(key)
+	if i < 0 {
+		return false
+	}
+
+	positions := h.index[hashed]
+	if len(positions) == 1 {
+		delete(h.index, hashed)
+	} else {
+		rest := make([]int, 0, len(positions)-1)
+		for _, j := range positions {
+			if j != i {
+				rest = append(rest, j)
+			}
+		}
+		h.index[hashed] = rest
+	}
+
+	h.pairs[i] = HashPair{}
+	h.deleted++
+	if h.deleted > len(h.pairs)/2 {
+		h.compact()
+	}
+	return true
+}
+
+// compact drops deleted entries so that they do not accumulate.
+func (h *Hash) compact() {
+	pairs := make([]HashPair, 0, h.Len())
+	h.index = make(map[HashKey][]int, len(h.index))
+	for _, pair := range h.pairs {
+		if pair.Key != nil {
+			hashed := pair.Key.(Hashable).HashKey()
+			h.index[hashed] = append(h.index[hashed], len(pairs))
+			pairs = append(pairs, pair)
+		}
+	}
+	h.pairs = pairs
+	h.deleted = 0
+}
+
+// Pairs returns the pairs in insertion order.
+func (h *Hash) Pairs() []HashPair {
+	pairs := make([]HashPair, 0, h.Len())
+	for _, pair := range h.pairs {
+		if pair.Key != nil {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
+
+// Copy returns a shallow copy of the hash.
+func (h *Hash) Copy() *Hash {
+	hash := &Hash{index: make(map[HashKey][]int, len(h.index))}
+	for _, pair := range h.pairs {
+		if pair.Key != nil {
+			hash.Set(pair.Key, pair.Value)
+		}
+	}
+	return hash
 }
 
 func (h *Hash) Len() int {
-	return len(h.Pairs)
+	return len(h.pairs) - h.deleted
 }
 
 func (h *Hash) Bool() bool {
-	return len(h.Pairs) > 0
+	return h.Len() > 0
 }
 
+// Compare reports whether both hashes hold equal values for the same keys,
+// regardless of their order.
 func (h *Hash) Compare(other Object) int {
 	obj, ok := other.(*Hash)
 	if !ok {
@@ -37,20 +157,18 @@ func (h *Hash) Compare(other Object) int {
 		return -1
 	}
 
-	for _, pair := range h.Pairs {
-		left := pair.Value
-		hashed := left.(Hashable)
-		right, ok := obj.Pairs[hashed.HashKey()]
+	for _, pair := range h.Pairs() {
+		right, ok := obj.Get(pair.Key)
 		if !ok {
 			return -1
 		}
 
-		cmp, ok := left.(Comparable)
+		cmp, ok := pair.Value.(Comparable)
 		if !ok {
 			return -1
 		}
-		if cmp.Compare(right.Value) != 0 {
-			return cmp.Compare(right.Value)
+		if c := cmp.Compare(right); c != 0 {
+			return c
 		}
 	}
 
@@ -67,7 +185,7 @@ func (h *Hash) Inspect() string {
 	var out strings.Builder
 
 	var pairs []string
-	for _, pair := range h.Pairs {
+	for _, pair := range h.Pairs() {
 		pairs = append(pairs, fmt.Sprintf("%s: %s", pair.Key.Inspect(), pair.Value.Inspect()))
 	}
 
@@ -1,20 +1,74 @@
 package object
 
 import (
+	"hash/fnv"
+	"math"
+	"math/big"
 	"strconv"
 )
 
 type Integer struct {
 	Value int64
+	Big   *big.Int // holds the value when it does not fit into Value
+}
+
+func NewInteger(value int64) *Integer {
+	return &Integer{Value: value}
+}
+
+// NewBigInteger returns an Integer for value, demoting it to an int64
+// representation whenever it fits.
+func NewBigInteger(value *big.Int) *Integer {
+	if value.IsInt64() {
+		return &Integer{Value: value.Int64()}
+	}
+	return &Integer{Big: value}
+}
+
+func (i *Integer) IsBig() bool {
+	return i.Big != nil
+}
+
+// BigValue returns a copy of the value as *big.Int which is safe to modify.
+func (i *Integer) BigValue() *big.Int {
+	if i.Big != nil {
+		return new(big.Int).Set(i.Big)
+	}
+	return big.NewInt(i.Value)
+}
+
+func (i *Integer) Float64() float64 {
+	if i.Big != nil {
+		f, _ := new(big.Float).SetInt(i.Big).Float64()
+		return f
+	}
+	return float64(i.Value)
+}
+
+func (i *Integer) Sign() int {
+	if i.Big != nil {
+		return i.Big.Sign()
+	}
+	switch {
+	case i.Value < 0:
+		return -1
+	case i.Value > 0:
+		return 1
+	default:
+		return 0
+	}
 }
 
 func (i *Integer) Bool() bool {
-	return i.Value != 0
+	return i.Big != nil || i.Value != 0
 }
 
 func (i *Integer) Compare(other Object) int {
 	switch obj := other.(type) {
 	case *Integer:
+		if i.Big != nil || obj.Big != nil {
+			return i.BigValue().Cmp(obj.BigValue())
+		}
 		switch {
 		case i.Value < obj.Value:
 			return -1
@@ -24,14 +78,7 @@ func (i *Integer) Compare(other Object) int {
 			return 0
 		}
 	case *Float:
-		switch {
-		case float64(i.Value) < obj.Value:
-			return -1
-		case float64(i.Value) > obj.Value:
-			return 1
-		default:
-			return 0
-		}
+		return -obj.compareInteger(i)
 	}
 
 	return -1
@@ -42,7 +89,15 @@ func (i *Integer) String() string {
 }
 
 func (i *Integer) Inspect() string {
-	return strconv.FormatInt(i.Value, 10)
+	return i.Text(10)
+}
+
+// Text returns the value in the given base without any prefix.
+func (i *Integer) Text(base int) string {
+	if i.Big != nil {
+		return i.Big.Text(base)
+	}
+	return strconv.FormatInt(i.Value, base)
 }
 
 func (i *Integer) Type() Type {
@@ -50,9 +105,42 @@ func (i *Integer) Type() Type {
 }
 
 func (i *Integer) Clone() Object {
+	if i.Big != nil {
+		return &Integer{Big: new(big.Int).Set(i.Big)}
+	}
 	return &Integer{Value: i.Value}
 }
 
 func (i *Integer) HashKey() HashKey {
+	if i.Big != nil {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte{byte(i.Big.Sign() + 1)})
+		_, _ = h.Write(i.Big.Bytes())
+
+		return HashKey{Type: i.Type(), Value: h.Sum64()}
+	}
+
 	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
 }
+
+// FloorDivMod returns the quotient rounded towards negative infinity and the
+// matching remainder, which takes the sign of the divisor. other must not be
+// zero.
+func (i *Integer) FloorDivMod(other *Integer) (*Integer, *Integer) {
+	if i.Big == nil && other.Big == nil && !(i.Value == math.MinInt64 && other.Value == -1) {
+		quo, rem := i.Value/other.Value, i.Value%other.Value
+		if rem != 0 && (rem < 0) != (other.Value < 0) {
+			quo--
+			rem += other.Value
+		}
+		return &Integer{Value: quo}, &Integer{Value: rem}
+	}
+
+	divisor := other.BigValue()
+	quo, rem := new(big.Int).QuoRem(i.BigValue(), divisor, new(big.Int))
+	if rem.Sign() != 0 && rem.Sign() != divisor.Sign() {
+		quo.Sub(quo, big.NewInt(1))
+		rem.Add(rem, divisor)
+	}
+	return NewBigInteger(quo), NewBigInteger(rem)
+}
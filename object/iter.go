@@ -0,0 +1,162 @@
+package object
+
+import "fmt"
+
+// Iterable is implemented by objects which can be looped over with for-in.
+type Iterable interface {
+	Iter() Iterator
+}
+
+// Iterator yields the elements of an Iterable one at a time. Next returns
+// false once there are no elements left.
+type Iterator interface {
+	Next() (Object, bool)
+}
+
+// Closer is implemented by iterators which hold on to resources until they
+// are exhausted, such as a suspended generator.
+type Closer interface {
+	Close()
+}
+
+// CloseIterator closes it if it is a Closer. Consumers which stop before an
+// iterator is exhausted call it so that a generator is not left suspended.
+func CloseIterator(it Iterator) {
+	if c, ok := it.(Closer); ok {
+		c.Close()
+	}
+}
+
+// Iter is an iterator object whose elements are produced lazily as they are
+// consumed, e.g. by a generator. It is iterable itself and can be consumed
+// only once.
+type Iter struct {
+	Name  string // the kind of iterator, e.g. generator
+	next  func() (Object, bool)
+	close func()
+	done  bool
+}
+
+// NewIter returns an iterator object which produces elements by calling
+// next and, when it is closed before being exhausted, calls close unless it
+// is nil.
+func NewIter(name string, next func() (Object, bool), close func()) *Iter {
+	return &Iter{Name: name, next: next, close: close}
+}
+
+// Next returns the next element. The iterator is exhausted after it returns
+// an error, and closed as it may have stopped early.
+func (it *Iter) Next() (Object, bool) {
+	if it.done {
+		return nil, false
+	}
+	obj, ok := it.next()
+	switch {
+	case !ok:
+		it.done = true
+	case obj.Type() == ErrorType:
+		it.Close()
+	}
+	return obj, ok
+}
+
+// Close stops the iterator and releases what it holds.
+func (it *Iter) Close() {
+	if it.done {
+		return
+	}
+	it.done = true
+	if it.close != nil {
+		it.close()
+	}
+}
+
+func (it *Iter) Iter() Iterator {
+	return it
+}
+
+func (it *Iter) Bool() bool {
+	return true
+}
+
+func (it *Iter) String() string {
+	return it.Inspect()
+}
+
+func (it *Iter) Inspect() string {
+	return fmt.Sprintf("<%s>", it.Name)
+}
+
+func (it *Iter) Type() Type {
+	return IterType
+}
+
+// sliceIterator iterates over a snapshot of a slice of elements.
+type sliceIterator struct {
+	elements []Object
+	pos      int
+}
+
+func (it *sliceIterator) Next() (Object, bool) {
+	if it.pos >= len(it.elements) {
+		return nil, false
+	}
+	it.pos++
+	return it.elements[it.pos-1], true
+}
+
+// arrayIterator iterates over an array by position, so that elements added
+// while iterating are visited too.
+type arrayIterator struct {
+	array *Array
+	pos   int
+}
+
+func (it *arrayIterator) Next() (Object, bool) {
+	if it.pos >= len(it.array.Elements) {
+		return nil, false
+	}
+	it.pos++
+	return it.array.Elements[it.pos-1], true
+}
+
+// stringIterator yields the characters of a string.
+type stringIterator struct {
+	runes []rune
+	pos   int
+}
+
+func (it *stringIterator) Next() (Object, bool) {
+	if it.pos >= len(it.runes) {
+		return nil, false
+	}
+	it.pos++
+	return &String{Value: string(it.runes[it.pos-1])}, true
+}
+
+func (a *Array) Iter() Iterator {
+	return &arrayIterator{array: a}
+}
+
+func (t *Tuple) Iter() Iterator {
+	return &sliceIterator{elements: t.Elements}
+}
+
+func (s *String) Iter() Iterator {
+	return &stringIterator{runes: []rune(s.Value)}
+}
+
+// Iter yields the keys of the hash as they were when iteration started.
+func (h *Hash) Iter() Iterator {
+	pairs := h.Pairs()
+	keys := make([]Object, len(pairs))
+	for i, pair := range pairs {
+		keys[i] = pair.Key
+	}
+	return &sliceIterator{elements: keys}
+}
+
+// Iter yields the elements of the set as they were when iteration started.
+func (s *Set) Iter() Iterator {
+	return &sliceIterator{elements: s.Elements()}
+}
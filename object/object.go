@@ -14,8 +14,37 @@ const (
 	BuiltInType  = "builtin"
 	ArrayType    = "array"
 	HashType     = "hash"
+	FileType     = "file"
+	RegexType    = "regex"
+	TimeType     = "time"
+	DurationType = "duration"
+	TupleType    = "tuple"
+	SetType      = "set"
+	StructType   = "struct"
+	MethodType   = "method"
+	RangeType    = "range"
+	IterType     = "iterator"
+	TaskType     = "task"
+	ChannelType  = "channel"
+	MutexType    = "mutex"
 )
 
+// builtinTypes are the types which user-defined structs cannot be named
+// after.
+var builtinTypes = map[Type]bool{
+	IntegerType: true, FloatType: true, StringType: true, BooleanType: true,
+	NullType: true, ReturnType: true, ErrorType: true, FunctionType: true,
+	BuiltInType: true, ArrayType: true, HashType: true, FileType: true,
+	RegexType: true, TimeType: true, DurationType: true, TupleType: true,
+	SetType: true, StructType: true, MethodType: true, RangeType: true,
+	IterType: true, TaskType: true, ChannelType: true, MutexType: true,
+}
+
+// IsBuiltinType reports whether t is the type of a builtin object.
+func IsBuiltinType(t Type) bool {
+	return builtinTypes[t]
+}
+
 type Object interface {
 	String() string
 	Type() Type
@@ -39,4 +68,6 @@ type Hashable interface {
 	HashKey() HashKey
 }
 
-type BuiltinFunction func(args ...Object) Object
+// BuiltinFunction is the implementation of a builtin. env is the environment
+// of the caller which is needed to call back into user functions with Apply.
+type BuiltinFunction func(env *Environment, args ...Object) Object
@@ -0,0 +1,69 @@
+package object
+
+import "fmt"
+
+// Range is an arithmetic progression of integers which are produced lazily
+// as it is iterated.
+type Range struct {
+	Start, Stop, Step int64 // Step is never zero
+}
+
+// count returns the number of integers in the range. The arithmetic is done
+// unsigned so that ranges spanning all of int64 do not overflow.
+func (r *Range) count() uint64 {
+	switch {
+	case r.Step > 0 && r.Start < r.Stop:
+		return (uint64(r.Stop)-uint64(r.Start)-1)/uint64(r.Step) + 1
+	case r.Step < 0 && r.Start > r.Stop:
+		return (uint64(r.Start)-uint64(r.Stop)-1)/(-uint64(r.Step)) + 1
+	default:
+		return 0
+	}
+}
+
+// Len returns the number of integers in the range, capped at the largest
+// int.
+func (r *Range) Len() int {
+	const maxInt = int(^uint(0) >> 1)
+	if n := r.count(); n < uint64(maxInt) {
+		return int(n)
+	}
+	return maxInt
+}
+
+func (r *Range) Iter() Iterator {
+	return &rangeIterator{r: r, n: r.count()}
+}
+
+func (r *Range) Bool() bool {
+	return r.count() > 0
+}
+
+func (r *Range) String() string {
+	return r.Inspect()
+}
+
+func (r *Range) Inspect() string {
+	if r.Step == 1 {
+		return fmt.Sprintf("range(%d, %d)", r.Start, r.Stop)
+	}
+	return fmt.Sprintf("range(%d, %d, %d)", r.Start, r.Stop, r.Step)
+}
+
+func (r *Range) Type() Type {
+	return RangeType
+}
+
+type rangeIterator struct {
+	r    *Range
+	i, n uint64
+}
+
+func (it *rangeIterator) Next() (Object, bool) {
+	if it.i >= it.n {
+		return nil, false
+	}
+	value := uint64(it.r.Start) + it.i*uint64(it.r.Step)
+	it.i++
+	return &Integer{Value: int64(value)}, true
+}
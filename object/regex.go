@@ -0,0 +1,42 @@
+package object
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+)
+
+// Regex is a compiled regular expression.
+type Regex struct {
+	Regexp *regexp.Regexp
+}
+
+func (r *Regex) Bool() bool {
+	return true
+}
+
+func (r *Regex) Compare(other Object) int {
+	if obj, ok := other.(*Regex); ok && obj.Regexp.String() == r.Regexp.String() {
+		return 0
+	}
+	return 1
+}
+
+func (r *Regex) String() string {
+	return r.Inspect()
+}
+
+func (r *Regex) Inspect() string {
+	return fmt.Sprintf("<regex %q>", r.Regexp.String())
+}
+
+func (r *Regex) Type() Type {
+	return RegexType
+}
+
+func (r *Regex) HashKey() HashKey {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(r.Regexp.String()))
+
+	return HashKey{Type: r.Type(), Value: h.Sum64()}
+}
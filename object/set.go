@@ -0,0 +1,146 @@
+package object
+
+import "strings"
+
+// Set is an unordered collection of distinct hashable elements. Elements
+// are kept in insertion order so that sets print deterministically.
+type Set struct {
+	elements Hash // elements are stored as keys with a nil value
+}
+
+func NewSet() *Set {
+	return &Set{}
+}
+
+// Add inserts obj, which must be hashable, and reports whether it was not
+// already present.
+func (s *Set) Add(obj Object) bool {
+	if s.Has(obj) {
+		return false
+	}
+	s.elements.Set(obj, nil)
+	return true
+}
+
+// Remove removes obj, which must be hashable, and reports whether it was
+// present.
+func (s *Set) Remove(obj Object) bool {
+	return s.elements.Delete(obj)
+}
+
+// Has reports whether obj, which must be hashable, is in the set.
+func (s *Set) Has(obj Object) bool {
+	_, ok := s.elements.Get(obj)
+	return ok
+}
+
+// Elements returns the elements in insertion order.
+func (s *Set) Elements() []Object {
+	pairs := s.elements.Pairs()
+	elements := make([]Object, len(pairs))
+	for i, pair := range pairs {
+		elements[i] = pair.Key
+	}
+	return elements
+}
+
+func (s *Set) Copy() *Set {
+	return &Set{elements: *s.elements.Copy()}
+}
+
+func (s *Set) Union(other *Set) *Set {
+	set := s.Copy()
+	for _, el := range other.Elements() {
+		set.Add(el)
+	}
+	return set
+}
+
+func (s *Set) Intersection(other *Set) *Set {
+	set := NewSet()
+	for _, el := range s.Elements() {
+		if other.Has(el) {
+			set.Add(el)
+		}
+	}
+	return set
+}
+
+func (s *Set) Difference(other *Set) *Set {
+	set := NewSet()
+	for _, el := range s.Elements() {
+		if !other.Has(el) {
+			set.Add(el)
+		}
+	}
+	return set
+}
+
+func (s *Set) SymmetricDifference(other *Set) *Set {
+	set := s.Difference(other)
+	for _, el := range other.Elements() {
+		if !s.Has(el) {
+			set.Add(el)
+		}
+	}
+	return set
+}
+
+// IsSubset reports whether every element of s is in other.
+func (s *Set) IsSubset(other *Set) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for _, el := range s.Elements() {
+		if !other.Has(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Set) Len() int {
+	return s.elements.Len()
+}
+
+func (s *Set) Bool() bool {
+	return s.Len() > 0
+}
+
+// Compare returns 0 for sets with the same elements and -1 otherwise. Sets
+// are only partially ordered by inclusion, which the evaluator handles for
+// the comparison operators.
+func (s *Set) Compare(other Object) int {
+	obj, ok := other.(*Set)
+	if !ok || s.Len() != obj.Len() || !s.IsSubset(obj) {
+		return -1
+	}
+	return 0
+}
+
+func (s *Set) String() string {
+	return s.Inspect()
+}
+
+func (s *Set) Inspect() string {
+	if s.Len() == 0 {
+		return "set()"
+	}
+
+	var out strings.Builder
+
+	var elems []string
+	for _, e := range s.Elements() {
+		elems = append(elems, e.Inspect())
+	}
+
+	out.WriteRune('{')
+	out.WriteString(strings.Join(elems, ", "))
+	out.WriteRune('}')
+
+	return out.String()
+}
+
+func (s *Set) Type() Type {
+	return SetType
+}
@@ -7,4 +7,20 @@ var (
 	Stdin     io.Reader
 	Stdout    io.Writer
 	ExitFn    func(int)
+
+	// Roots lists the directories scripts may access through the file
+	// system builtins. File access is disabled when it is empty.
+	Roots []string
+
+	// AllowProcess enables the builtins which access the environment
+	// variables, the working directory and run commands.
+	AllowProcess bool
+
+	// StrictIndex makes reading an index out of range an IndexError rather
+	// than giving null, or "" for strings.
+	StrictIndex bool
+
+	// Clock is used by the time module. Hosts may replace it, e.g. with a
+	// ManualClock, to make scripts deterministic.
+	Clock = SystemClock
 )
@@ -0,0 +1,210 @@
+package object
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ars2014/ulang/ast"
+)
+
+// Struct is a user-defined type. Calling it constructs an Instance.
+type Struct struct {
+	Name    string
+	Fields  []*ast.StructField
+	Methods map[string]*Function
+	Env     *Environment // evaluates the default values of fields
+}
+
+// FieldIndex returns the position of the named field.
+func (s *Struct) FieldIndex(name string) (int, bool) {
+	for i, field := range s.Fields {
+		if field.Name.Value == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (s *Struct) Bool() bool {
+	return true
+}
+
+func (s *Struct) String() string {
+	return s.Inspect()
+}
+
+func (s *Struct) Inspect() string {
+	return fmt.Sprintf("<struct %s>", s.Name)
+}
+
+func (s *Struct) Type() Type {
+	return StructType
+}
+
+// Instance is a value of a user-defined struct type. Its type is the name
+// of the struct.
+type Instance struct {
+	Struct *Struct
+	Fields []Object // in the order of Struct.Fields
+
+	inspecting bool // set while __str__ runs to stop it recursing
+}
+
+// Method returns the named method bound to the instance.
+func (i *Instance) Method(name string) (*BoundMethod, bool) {
+	method, ok := i.Struct.Methods[name]
+	if !ok {
+		return nil, false
+	}
+	return &BoundMethod{Self: i, Name: name, Method: method}, true
+}
+
+// call calls a protocol method from outside of the evaluator, where there
+// is no environment of a caller. An error of the method is raised to the
+// evaluator and returned.
+func (i *Instance) call(name string, args ...Object) (Object, bool) {
+	method, ok := i.Method(name)
+	if !ok {
+		return nil, false
+	}
+	result := Apply(i.Struct.Env.Frame(), method, args)
+	if err, isErr := result.(*Error); isErr {
+		i.Struct.Env.Raise(err)
+	}
+	return result, true
+}
+
+// Get returns the value of a field or the method bound to the instance.
+func (i *Instance) Get(name string) (Object, bool) {
+	if idx, ok := i.Struct.FieldIndex(name); ok {
+		return i.Fields[idx], true
+	}
+	if method, ok := i.Method(name); ok {
+		return method, true
+	}
+	return nil, false
+}
+
+// Set assigns a field and reports whether the struct has it.
+func (i *Instance) Set(name string, value Object) bool {
+	idx, ok := i.Struct.FieldIndex(name)
+	if ok {
+		i.Fields[idx] = value
+	}
+	return ok
+}
+
+func (i *Instance) Bool() bool {
+	return true
+}
+
+// Compare uses the __eq__ and __lt__ methods when the struct declares them.
+// Otherwise it reports whether both instances are of the same struct and
+// have equal fields.
+func (i *Instance) Compare(other Object) int {
+	eq, hasEq := i.call("__eq__", other)
+	if hasEq && eq.Type() == ErrorType {
+		return -1
+	}
+	if hasEq && eq.Bool() {
+		return 0
+	}
+	if lt, ok := i.call("__lt__", other); ok {
+		switch {
+		case lt.Type() == ErrorType:
+			return -1
+		case lt.Bool():
+			return -1
+		case hasEq:
+			return 1
+		}
+		// Without __eq__ the instances are equal when neither is less.
+		if obj, ok := other.(*Instance); ok {
+			if gt, ok := obj.call("__lt__", i); ok && gt.Type() != ErrorType && !gt.Bool() {
+				return 0
+			}
+		}
+		return 1
+	}
+	if hasEq {
+		return -1
+	}
+
+	obj, ok := other.(*Instance)
+	if !ok || obj.Struct != i.Struct {
+		return -1
+	}
+
+	for idx, field := range i.Fields {
+		cmp, ok := field.(Comparable)
+		if !ok {
+			if field != obj.Fields[idx] {
+				return -1
+			}
+			continue
+		}
+		if c := cmp.Compare(obj.Fields[idx]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func (i *Instance) String() string {
+	return i.Inspect()
+}
+
+// Inspect returns the result of the __str__ method when the struct
+// declares it.
+func (i *Instance) Inspect() string {
+	if !i.inspecting {
+		i.inspecting = true
+		result, ok := i.call("__str__")
+		i.inspecting = false
+		if str, isStr := result.(*String); ok && isStr {
+			return str.Value
+		}
+	}
+
+	var out strings.Builder
+
+	var fields []string
+	for idx, field := range i.Struct.Fields {
+		fields = append(fields, fmt.Sprintf("%s: %s", field.Name.Value, i.Fields[idx].Inspect()))
+	}
+
+	out.WriteString(i.Struct.Name)
+	out.WriteRune('{')
+	out.WriteString(strings.Join(fields, ", "))
+	out.WriteRune('}')
+
+	return out.String()
+}
+
+func (i *Instance) Type() Type {
+	return Type(i.Struct.Name)
+}
+
+// BoundMethod is a method selected from an instance, which is bound to self
+// when it is called.
+type BoundMethod struct {
+	Self   *Instance
+	Name   string
+	Method *Function
+}
+
+func (b *BoundMethod) Bool() bool {
+	return true
+}
+
+func (b *BoundMethod) String() string {
+	return b.Inspect()
+}
+
+func (b *BoundMethod) Inspect() string {
+	return fmt.Sprintf("<method %s.%s>", b.Self.Struct.Name, b.Name)
+}
+
+func (b *BoundMethod) Type() Type {
+	return MethodType
+}
@@ -0,0 +1,188 @@
+package object
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Task is a function running concurrently, as started by spawn.
+type Task struct {
+	done   chan struct{}
+	result Object
+}
+
+func NewTask() *Task {
+	return &Task{done: make(chan struct{})}
+}
+
+// Finish records the result of the task and wakes up those waiting for it.
+func (t *Task) Finish(result Object) {
+	t.result = result
+	close(t.done)
+}
+
+// Done returns a channel which is closed when the task has finished.
+func (t *Task) Done() <-chan struct{} {
+	return t.done
+}
+
+// Result returns the result of a finished task.
+func (t *Task) Result() Object {
+	<-t.done
+	return t.result
+}
+
+func (t *Task) Bool() bool {
+	return true
+}
+
+func (t *Task) String() string {
+	return t.Inspect()
+}
+
+func (t *Task) Inspect() string {
+	select {
+	case <-t.done:
+		return "<task done>"
+	default:
+		return "<task running>"
+	}
+}
+
+func (t *Task) Type() Type {
+	return TaskType
+}
+
+// Channel passes objects between tasks. Sending to an unbuffered channel
+// blocks until another task receives.
+type Channel struct {
+	ch  chan Object
+	env *Environment // the interpreter whose tasks run while blocked
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func NewChannel(env *Environment, size int) *Channel {
+	return &Channel{ch: make(chan Object, size), env: env}
+}
+
+// Chan returns the underlying channel, e.g. to select on it.
+func (c *Channel) Chan() chan Object {
+	return c.ch
+}
+
+// Send sends obj and reports false if the channel is closed.
+func (c *Channel) Send(obj Object) (ok bool) {
+	defer func() {
+		// The channel was closed while the send was blocked.
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	if c.Closed() {
+		return false
+	}
+	c.env.Block(func() { c.ch <- obj })
+	return true
+}
+
+// Recv receives an object and reports false once the channel is closed and
+// has no objects left.
+func (c *Channel) Recv() (obj Object, ok bool) {
+	c.env.Block(func() { obj, ok = <-c.ch })
+	return obj, ok
+}
+
+// Close closes the channel and reports false if it already was.
+func (c *Channel) Close() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return false
+	}
+	c.closed = true
+	close(c.ch)
+	return true
+}
+
+func (c *Channel) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// Len returns the number of objects buffered in the channel.
+func (c *Channel) Len() int {
+	return len(c.ch)
+}
+
+// Iter receives objects until the channel is closed.
+func (c *Channel) Iter() Iterator {
+	return c
+}
+
+func (c *Channel) Next() (Object, bool) {
+	return c.Recv()
+}
+
+func (c *Channel) Bool() bool {
+	return true
+}
+
+func (c *Channel) String() string {
+	return c.Inspect()
+}
+
+func (c *Channel) Inspect() string {
+	return fmt.Sprintf("<channel %d/%d>", len(c.ch), cap(c.ch))
+}
+
+func (c *Channel) Type() Type {
+	return ChannelType
+}
+
+// Mutex is a lock which tasks can hold across blocking operations. It is not
+// reentrant.
+type Mutex struct {
+	ch  chan struct{} // holds a value while locked
+	env *Environment
+}
+
+func NewMutex(env *Environment) *Mutex {
+	return &Mutex{ch: make(chan struct{}, 1), env: env}
+}
+
+func (m *Mutex) Lock() {
+	m.env.Block(func() { m.ch <- struct{}{} })
+}
+
+// Unlock unlocks the mutex and reports false if it was not locked.
+func (m *Mutex) Unlock() bool {
+	select {
+	case <-m.ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *Mutex) Bool() bool {
+	return true
+}
+
+func (m *Mutex) String() string {
+	return m.Inspect()
+}
+
+func (m *Mutex) Inspect() string {
+	if len(m.ch) > 0 {
+		return "<mutex locked>"
+	}
+	return "<mutex>"
+}
+
+func (m *Mutex) Type() Type {
+	return MutexType
+}
@@ -0,0 +1,146 @@
+package object
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Time is an instant in time together with the location it is displayed in.
+// Times are equal when they denote the same instant.
+type Time struct {
+	Value time.Time
+}
+
+func (t *Time) Bool() bool {
+	return true
+}
+
+func (t *Time) Compare(other Object) int {
+	if obj, ok := other.(*Time); ok {
+		switch {
+		case t.Value.Before(obj.Value):
+			return -1
+		case t.Value.After(obj.Value):
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return 1
+}
+
+func (t *Time) String() string {
+	return t.Inspect()
+}
+
+func (t *Time) Inspect() string {
+	return t.Value.Format(time.RFC3339Nano)
+}
+
+func (t *Time) Type() Type {
+	return TimeType
+}
+
+func (t *Time) HashKey() HashKey {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strconv.FormatInt(t.Value.Unix(), 10)))
+	_, _ = h.Write([]byte(strconv.Itoa(t.Value.Nanosecond())))
+
+	return HashKey{Type: t.Type(), Value: h.Sum64()}
+}
+
+type Duration struct {
+	Value time.Duration
+}
+
+func (d *Duration) Bool() bool {
+	return d.Value != 0
+}
+
+func (d *Duration) Compare(other Object) int {
+	if obj, ok := other.(*Duration); ok {
+		switch {
+		case d.Value < obj.Value:
+			return -1
+		case d.Value > obj.Value:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return 1
+}
+
+func (d *Duration) String() string {
+	return d.Inspect()
+}
+
+func (d *Duration) Inspect() string {
+	return d.Value.String()
+}
+
+func (d *Duration) Type() Type {
+	return DurationType
+}
+
+func (d *Duration) HashKey() HashKey {
+	return HashKey{Type: d.Type(), Value: uint64(d.Value)}
+}
+
+// TimeSource is the source of time used by the time module.
+type TimeSource interface {
+	Now() time.Time
+	// Monotonic returns the time elapsed since an arbitrary fixed point.
+	Monotonic() time.Duration
+	Sleep(d time.Duration)
+}
+
+type systemClock struct {
+	start time.Time
+}
+
+func (c *systemClock) Now() time.Time           { return time.Now() }
+func (c *systemClock) Monotonic() time.Duration { return time.Since(c.start) }
+func (c *systemClock) Sleep(d time.Duration)    { time.Sleep(d) }
+
+// SystemClock reads the time of the host.
+var SystemClock TimeSource = &systemClock{start: time.Now()}
+
+// ManualClock is a TimeSource for tests which only advances when Sleep or
+// Advance is called.
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	elapsed time.Duration
+}
+
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) Monotonic() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.elapsed
+}
+
+func (c *ManualClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.elapsed += d
+}
@@ -0,0 +1,109 @@
+package object
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"strings"
+)
+
+// Tuple is an immutable sequence. It can be used as a hash key when all of
+// its elements can.
+type Tuple struct {
+	Elements []Object
+}
+
+func (t *Tuple) Len() int {
+	return len(t.Elements)
+}
+
+func (t *Tuple) Bool() bool {
+	return len(t.Elements) > 0
+}
+
+// Compare orders tuples lexicographically.
+func (t *Tuple) Compare(other Object) int {
+	obj, ok := other.(*Tuple)
+	if !ok {
+		return -1
+	}
+
+	for i, el := range t.Elements {
+		if i >= len(obj.Elements) {
+			return 1
+		}
+		cmp, ok := el.(Comparable)
+		if !ok {
+			return -1
+		}
+		if c := cmp.Compare(obj.Elements[i]); c != 0 {
+			return c
+		}
+	}
+
+	if len(t.Elements) < len(obj.Elements) {
+		return -1
+	}
+	return 0
+}
+
+func (t *Tuple) String() string {
+	return t.Inspect()
+}
+
+func (t *Tuple) Inspect() string {
+	var out strings.Builder
+
+	var elems []string
+	for _, e := range t.Elements {
+		elems = append(elems, e.Inspect())
+	}
+
+	out.WriteRune('(')
+	out.WriteString(strings.Join(elems, ", "))
+	if len(elems) == 1 {
+		out.WriteRune(',')
+	}
+	out.WriteRune(')')
+
+	return out.String()
+}
+
+func (t *Tuple) Type() Type {
+	return TupleType
+}
+
+func (t *Tuple) Clone() Object {
+	elements := make([]Object, len(t.Elements))
+	copy(elements, t.Elements)
+	return &Tuple{Elements: elements}
+}
+
+// HashKey combines the hash keys of the elements, which must all be
+// hashable. Use IsHashable to check.
+func (t *Tuple) HashKey() HashKey {
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, el := range t.Elements {
+		key := el.(Hashable).HashKey()
+		_, _ = h.Write([]byte(key.Type))
+		binary.LittleEndian.PutUint64(buf[:], key.Value)
+		_, _ = h.Write(buf[:])
+	}
+
+	return HashKey{Type: t.Type(), Value: h.Sum64()}
+}
+
+// IsHashable reports whether obj can be used as a hash key.
+func IsHashable(obj Object) bool {
+	if t, ok := obj.(*Tuple); ok {
+		for _, el := range t.Elements {
+			if !IsHashable(el) {
+				return false
+			}
+		}
+		return true
+	}
+
+	_, ok := obj.(Hashable)
+	return ok
+}
@@ -11,9 +11,9 @@ import (
 )
 
 const (
-	numProductions = 102
-	numStates      = 1926
-	numSymbols     = 81
+	numProductions = 183
+	numStates      = 5022
+	numSymbols     = 111
 )
 
 // Stack
@@ -23,7 +23,7 @@ type (
 
 var productionsTable = ProdTab{
 	ProdTabEntry{
-		String: `S' : Program	<<  >>`,
+		String:     `S' : Program	<<  >>`,
 		Id:         "S'",
 		NTType:     0,
 		Index:      0,
@@ -33,7 +33,7 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Program : StatementList	<< ast.NewProgram(X[0].(ast.StatementList)) >>`,
+		String:     `Program : StatementList	<< ast.NewProgram(X[0].(ast.StatementList)) >>`,
 		Id:         "Program",
 		NTType:     1,
 		Index:      1,
@@ -43,7 +43,7 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `StatementList : Statement	<< ast.NewStatementList(X[0].(ast.Statement)) >>`,
+		String:     `StatementList : Statement	<< ast.NewStatementList(X[0].(ast.Statement)) >>`,
 		Id:         "StatementList",
 		NTType:     2,
 		Index:      2,
@@ -53,7 +53,7 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `StatementList : StatementList terminator Statement	<< ast.AppendStatement(X[0].(ast.StatementList), X[2].(ast.Statement)) >>`,
+		String:     `StatementList : StatementList terminator Statement	<< ast.AppendStatement(X[0].(ast.StatementList), X[2].(ast.Statement)) >>`,
 		Id:         "StatementList",
 		NTType:     2,
 		Index:      3,
@@ -63,7 +63,7 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `StatementList : StatementList terminator	<< X[0], nil >>`,
+		String:     `StatementList : StatementList terminator	<< X[0], nil >>`,
 		Id:         "StatementList",
 		NTType:     2,
 		Index:      4,
@@ -73,7 +73,7 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Statement : BlockStatement	<<  >>`,
+		String:     `Statement : BlockStatement	<<  >>`,
 		Id:         "Statement",
 		NTType:     3,
 		Index:      5,
@@ -83,7 +83,7 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Statement : ReturnStatement	<<  >>`,
+		String:     `Statement : ReturnStatement	<<  >>`,
 		Id:         "Statement",
 		NTType:     3,
 		Index:      6,
@@ -93,7 +93,7 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Statement : ExpressionStatement	<<  >>`,
+		String:     `Statement : YieldStatement	<<  >>`,
 		Id:         "Statement",
 		NTType:     3,
 		Index:      7,
@@ -103,229 +103,229 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `BlockStatement : "{" StatementList "}"	<< ast.NewBlockStatement(X[0].(*token.Token), X[1].(ast.StatementList)) >>`,
+		String:     `Statement : ExpressionStatement	<<  >>`,
+		Id:         "Statement",
+		NTType:     3,
+		Index:      8,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `BlockStatement : "{" StatementList "}"	<< ast.NewBlockStatement(X[0].(*token.Token), X[1].(ast.StatementList)) >>`,
 		Id:         "BlockStatement",
 		NTType:     4,
-		Index:      8,
+		Index:      9,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewBlockStatement(X[0].(*token.Token), X[1].(ast.StatementList))
 		},
 	},
 	ProdTabEntry{
-		String: `BlockStatement : "{" "}"	<< ast.NewBlockStatement(X[0].(*token.Token), ast.StatementList{}) >>`,
+		String:     `BlockStatement : "{" "}"	<< ast.NewBlockStatement(X[0].(*token.Token), ast.StatementList{}) >>`,
 		Id:         "BlockStatement",
 		NTType:     4,
-		Index:      9,
+		Index:      10,
 		NumSymbols: 2,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewBlockStatement(X[0].(*token.Token), ast.StatementList{})
 		},
 	},
 	ProdTabEntry{
-		String: `ReturnStatement : kwdReturn	<< ast.NewReturnStatement(X[0].(*token.Token), nil) >>`,
+		String:     `ReturnStatement : kwdReturn	<< ast.NewReturnStatement(X[0].(*token.Token), nil) >>`,
 		Id:         "ReturnStatement",
 		NTType:     5,
-		Index:      10,
+		Index:      11,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewReturnStatement(X[0].(*token.Token), nil)
 		},
 	},
 	ProdTabEntry{
-		String: `ReturnStatement : kwdReturn Expression	<< ast.NewReturnStatement(X[0].(*token.Token), X[1].(ast.Expression)) >>`,
+		String:     `ReturnStatement : kwdReturn Expression	<< ast.NewReturnStatement(X[0].(*token.Token), X[1].(ast.Expression)) >>`,
 		Id:         "ReturnStatement",
 		NTType:     5,
-		Index:      11,
+		Index:      12,
 		NumSymbols: 2,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewReturnStatement(X[0].(*token.Token), X[1].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ExpressionStatement : Expression	<< ast.NewExpressionStatement(X[0].(ast.Expression)) >>`,
-		Id:         "ExpressionStatement",
+		String:     `YieldStatement : kwdYield	<< ast.NewYieldStatement(X[0].(*token.Token), nil) >>`,
+		Id:         "YieldStatement",
 		NTType:     6,
-		Index:      12,
-		NumSymbols: 1,
-		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewExpressionStatement(X[0].(ast.Expression))
-		},
-	},
-	ProdTabEntry{
-		String: `ExpressionList : Expression	<< ast.NewExpressionList(X[0].(ast.Expression)) >>`,
-		Id:         "ExpressionList",
-		NTType:     7,
 		Index:      13,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewExpressionList(X[0].(ast.Expression))
+			return ast.NewYieldStatement(X[0].(*token.Token), nil)
 		},
 	},
 	ProdTabEntry{
-		String: `ExpressionList : ExpressionList "," Expression	<< ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression)) >>`,
-		Id:         "ExpressionList",
-		NTType:     7,
+		String:     `YieldStatement : kwdYield Expression	<< ast.NewYieldStatement(X[0].(*token.Token), X[1].(ast.Expression)) >>`,
+		Id:         "YieldStatement",
+		NTType:     6,
 		Index:      14,
-		NumSymbols: 3,
+		NumSymbols: 2,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression))
+			return ast.NewYieldStatement(X[0].(*token.Token), X[1].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ExpressionList : ExpressionList ","	<< X[0], nil >>`,
-		Id:         "ExpressionList",
+		String:     `ExpressionStatement : Expression	<< ast.NewExpressionStatement(X[0].(ast.Expression)) >>`,
+		Id:         "ExpressionStatement",
 		NTType:     7,
 		Index:      15,
-		NumSymbols: 2,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewExpressionStatement(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ExpressionMap : Expression ":" Expression	<< ast.NewExpressionMap(X[0].(ast.Expression), X[2].(ast.Expression)) >>`,
-		Id:         "ExpressionMap",
-		NTType:     8,
+		String:     `ExpressionStatement : MultipleAssignment	<< ast.NewExpressionStatement(X[0].(ast.Expression)) >>`,
+		Id:         "ExpressionStatement",
+		NTType:     7,
 		Index:      16,
-		NumSymbols: 3,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewExpressionMap(X[0].(ast.Expression), X[2].(ast.Expression))
+			return ast.NewExpressionStatement(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ExpressionMap : ExpressionMap "," Expression ":" Expression	<< ast.AppendExpressionPair(X[0].(ast.ExpressionMap), X[2].(ast.Expression), X[4].(ast.Expression)) >>`,
-		Id:         "ExpressionMap",
-		NTType:     8,
+		String:     `ExpressionStatement : IncDecExpression	<< ast.NewExpressionStatement(X[0].(ast.Expression)) >>`,
+		Id:         "ExpressionStatement",
+		NTType:     7,
 		Index:      17,
-		NumSymbols: 5,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.AppendExpressionPair(X[0].(ast.ExpressionMap), X[2].(ast.Expression), X[4].(ast.Expression))
+			return ast.NewExpressionStatement(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ExpressionMap : ExpressionMap ","	<< X[0], nil >>`,
-		Id:         "ExpressionMap",
-		NTType:     8,
+		String:     `ExpressionStatement : PatternAssignment	<< ast.NewExpressionStatement(X[0].(ast.Expression)) >>`,
+		Id:         "ExpressionStatement",
+		NTType:     7,
 		Index:      18,
-		NumSymbols: 2,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewExpressionStatement(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Expression : Term1	<<  >>`,
-		Id:         "Expression",
-		NTType:     9,
+		String:     `PatternAssignment : BlockPattern assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "PatternAssignment",
+		NTType:     8,
 		Index:      19,
-		NumSymbols: 1,
+		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Expression : IfExpression	<<  >>`,
-		Id:         "Expression",
+		String:     `MultipleAssignment : Expression "," AssignExpression	<< ast.NewMultipleAssignment(X[0].(ast.Expression), X[1].(*token.Token), nil, X[2].(*ast.AssignExpression), nil) >>`,
+		Id:         "MultipleAssignment",
 		NTType:     9,
 		Index:      20,
-		NumSymbols: 1,
+		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewMultipleAssignment(X[0].(ast.Expression), X[1].(*token.Token), nil, X[2].(*ast.AssignExpression), nil)
 		},
 	},
 	ProdTabEntry{
-		String: `Expression : ForExpression	<<  >>`,
-		Id:         "Expression",
+		String:     `MultipleAssignment : Expression "," AssignExpression "," ExpressionList	<< ast.NewMultipleAssignment(X[0].(ast.Expression), X[1].(*token.Token), nil, X[2].(*ast.AssignExpression), X[4].(ast.ExpressionList)) >>`,
+		Id:         "MultipleAssignment",
 		NTType:     9,
 		Index:      21,
-		NumSymbols: 1,
+		NumSymbols: 5,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewMultipleAssignment(X[0].(ast.Expression), X[1].(*token.Token), nil, X[2].(*ast.AssignExpression), X[4].(ast.ExpressionList))
 		},
 	},
 	ProdTabEntry{
-		String: `Expression : AssignExpression	<<  >>`,
-		Id:         "Expression",
+		String:     `MultipleAssignment : Expression "," PlainExpressionList "," AssignExpression	<< ast.NewMultipleAssignment(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.ExpressionList), X[4].(*ast.AssignExpression), nil) >>`,
+		Id:         "MultipleAssignment",
 		NTType:     9,
 		Index:      22,
-		NumSymbols: 1,
+		NumSymbols: 5,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewMultipleAssignment(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.ExpressionList), X[4].(*ast.AssignExpression), nil)
 		},
 	},
 	ProdTabEntry{
-		String: `Term1 : Term1 lOr Term2	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term1",
-		NTType:     10,
+		String:     `MultipleAssignment : Expression "," PlainExpressionList "," AssignExpression "," ExpressionList	<< ast.NewMultipleAssignment(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.ExpressionList), X[4].(*ast.AssignExpression), X[6].(ast.ExpressionList)) >>`,
+		Id:         "MultipleAssignment",
+		NTType:     9,
 		Index:      23,
-		NumSymbols: 3,
+		NumSymbols: 7,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return ast.NewMultipleAssignment(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.ExpressionList), X[4].(*ast.AssignExpression), X[6].(ast.ExpressionList))
 		},
 	},
 	ProdTabEntry{
-		String: `Term1 : Term2	<<  >>`,
-		Id:         "Term1",
+		String:     `ExpressionList : Expression	<< ast.NewExpressionList(X[0].(ast.Expression)) >>`,
+		Id:         "ExpressionList",
 		NTType:     10,
 		Index:      24,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewExpressionList(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Term2 : Term2 lAnd Term3	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term2",
-		NTType:     11,
+		String:     `ExpressionList : ExpressionList "," Expression	<< ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression)) >>`,
+		Id:         "ExpressionList",
+		NTType:     10,
 		Index:      25,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Term2 : Term3	<<  >>`,
-		Id:         "Term2",
-		NTType:     11,
+		String:     `ExpressionList : ExpressionList ","	<< X[0], nil >>`,
+		Id:         "ExpressionList",
+		NTType:     10,
 		Index:      26,
-		NumSymbols: 1,
+		NumSymbols: 2,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Term3 : Term3 lNot Term4	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term3",
-		NTType:     12,
+		String:     `ExpressionMap : Expression ":" Expression	<< ast.NewExpressionMap(X[0].(ast.Expression), X[2].(ast.Expression)) >>`,
+		Id:         "ExpressionMap",
+		NTType:     11,
 		Index:      27,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return ast.NewExpressionMap(X[0].(ast.Expression), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Term3 : Term4	<<  >>`,
-		Id:         "Term3",
-		NTType:     12,
+		String:     `ExpressionMap : ExpressionMap "," Expression ":" Expression	<< ast.AppendExpressionPair(X[0].(ast.ExpressionMap), X[2].(ast.Expression), X[4].(ast.Expression)) >>`,
+		Id:         "ExpressionMap",
+		NTType:     11,
 		Index:      28,
-		NumSymbols: 1,
+		NumSymbols: 5,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.AppendExpressionPair(X[0].(ast.ExpressionMap), X[2].(ast.Expression), X[4].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Term4 : Term4 equals Term5	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term4",
-		NTType:     13,
+		String:     `ExpressionMap : ExpressionMap ","	<< X[0], nil >>`,
+		Id:         "ExpressionMap",
+		NTType:     11,
 		Index:      29,
-		NumSymbols: 3,
+		NumSymbols: 2,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Term4 : Term5	<<  >>`,
-		Id:         "Term4",
-		NTType:     13,
+		String:     `Expression : PlainExpression	<<  >>`,
+		Id:         "Expression",
+		NTType:     12,
 		Index:      30,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
@@ -333,19 +333,19 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Term5 : Term5 lessOrGreater Term6	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term5",
-		NTType:     14,
+		String:     `Expression : AssignExpression	<<  >>`,
+		Id:         "Expression",
+		NTType:     12,
 		Index:      31,
-		NumSymbols: 3,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Term5 : Term6	<<  >>`,
-		Id:         "Term5",
-		NTType:     14,
+		String:     `Expression : CompoundAssignExpression	<<  >>`,
+		Id:         "Expression",
+		NTType:     12,
 		Index:      32,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
@@ -353,39 +353,39 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Term6 : Term6 or Term7	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term6",
-		NTType:     15,
+		String:     `PlainExpressionList : PlainExpression	<< ast.NewExpressionList(X[0].(ast.Expression)) >>`,
+		Id:         "PlainExpressionList",
+		NTType:     13,
 		Index:      33,
-		NumSymbols: 3,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return ast.NewExpressionList(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Term6 : Term7	<<  >>`,
-		Id:         "Term6",
-		NTType:     15,
+		String:     `PlainExpressionList : PlainExpressionList "," PlainExpression	<< ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression)) >>`,
+		Id:         "PlainExpressionList",
+		NTType:     13,
 		Index:      34,
-		NumSymbols: 1,
+		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Term7 : Term7 xor Term8	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term7",
-		NTType:     16,
+		String:     `PlainExpressionList : PlainExpressionList ","	<< X[0], nil >>`,
+		Id:         "PlainExpressionList",
+		NTType:     13,
 		Index:      35,
-		NumSymbols: 3,
+		NumSymbols: 2,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Term7 : Term8	<<  >>`,
-		Id:         "Term7",
-		NTType:     16,
+		String:     `PlainExpression : Term1	<<  >>`,
+		Id:         "PlainExpression",
+		NTType:     14,
 		Index:      36,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
@@ -393,19 +393,19 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Term8 : Term8 and Term9	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term8",
-		NTType:     17,
+		String:     `PlainExpression : IfExpression	<<  >>`,
+		Id:         "PlainExpression",
+		NTType:     14,
 		Index:      37,
-		NumSymbols: 3,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Term8 : Term9	<<  >>`,
-		Id:         "Term8",
-		NTType:     17,
+		String:     `PlainExpression : ForExpression	<<  >>`,
+		Id:         "PlainExpression",
+		NTType:     14,
 		Index:      38,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
@@ -413,39 +413,39 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Term9 : Term9 shift Term10	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term9",
-		NTType:     18,
+		String:     `PlainExpression : StructDeclaration	<<  >>`,
+		Id:         "PlainExpression",
+		NTType:     14,
 		Index:      39,
-		NumSymbols: 3,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Term9 : Term10	<<  >>`,
-		Id:         "Term9",
-		NTType:     18,
+		String:     `Term1 : Term1 lOr Term2	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term1",
+		NTType:     15,
 		Index:      40,
-		NumSymbols: 1,
+		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Term10 : Term10 "+" Term11	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term10",
-		NTType:     19,
+		String:     `Term1 : Term2	<<  >>`,
+		Id:         "Term1",
+		NTType:     15,
 		Index:      41,
-		NumSymbols: 3,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Term10 : Term10 "-" Term11	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term10",
-		NTType:     19,
+		String:     `Term2 : Term2 lAnd Term3	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term2",
+		NTType:     16,
 		Index:      42,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
@@ -453,9 +453,9 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Term10 : Term11	<<  >>`,
-		Id:         "Term10",
-		NTType:     19,
+		String:     `Term2 : Term3	<<  >>`,
+		Id:         "Term2",
+		NTType:     16,
 		Index:      43,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
@@ -463,9 +463,9 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Term11 : Term11 product Term12	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "Term11",
-		NTType:     20,
+		String:     `Term3 : Term3 nullish Term4	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term3",
+		NTType:     17,
 		Index:      44,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
@@ -473,9 +473,9 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Term11 : Term12	<<  >>`,
-		Id:         "Term11",
-		NTType:     20,
+		String:     `Term3 : Term4	<<  >>`,
+		Id:         "Term3",
+		NTType:     17,
 		Index:      45,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
@@ -483,59 +483,59 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `Term12 : PrefixExpression	<<  >>`,
-		Id:         "Term12",
-		NTType:     21,
+		String:     `Term4 : Term4 equals Term5	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term4",
+		NTType:     18,
 		Index:      46,
-		NumSymbols: 1,
+		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Term12 : "(" Expression ")"	<< X[1], nil >>`,
-		Id:         "Term12",
-		NTType:     21,
+		String:     `Term4 : Term5	<<  >>`,
+		Id:         "Term4",
+		NTType:     18,
 		Index:      47,
-		NumSymbols: 3,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[1], nil
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `PrefixExpression : PrimaryExpr	<<  >>`,
-		Id:         "PrefixExpression",
-		NTType:     22,
+		String:     `Term5 : Term5 lessOrGreater Term6	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term5",
+		NTType:     19,
 		Index:      48,
-		NumSymbols: 1,
+		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `PrefixExpression : PrefixOp PrimaryExpr	<< ast.NewPrefixExpression(X[0].(*token.Token), X[1].(ast.Expression)) >>`,
-		Id:         "PrefixExpression",
-		NTType:     22,
+		String:     `Term5 : Term6	<<  >>`,
+		Id:         "Term5",
+		NTType:     19,
 		Index:      49,
-		NumSymbols: 2,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewPrefixExpression(X[0].(*token.Token), X[1].(ast.Expression))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `PrefixOp : "+"	<<  >>`,
-		Id:         "PrefixOp",
-		NTType:     23,
+		String:     `Term6 : Term6 or Term7	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term6",
+		NTType:     20,
 		Index:      50,
-		NumSymbols: 1,
+		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `PrefixOp : "-"	<<  >>`,
-		Id:         "PrefixOp",
-		NTType:     23,
+		String:     `Term6 : Term7	<<  >>`,
+		Id:         "Term6",
+		NTType:     20,
 		Index:      51,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
@@ -543,503 +543,1313 @@ var productionsTable = ProdTab{
 		},
 	},
 	ProdTabEntry{
-		String: `PrefixOp : "!"	<<  >>`,
-		Id:         "PrefixOp",
-		NTType:     23,
+		String:     `Term7 : Term7 xor Term8	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term7",
+		NTType:     21,
 		Index:      52,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `Term7 : Term8	<<  >>`,
+		Id:         "Term7",
+		NTType:     21,
+		Index:      53,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `PrefixOp : "~"	<<  >>`,
-		Id:         "PrefixOp",
+		String:     `Term8 : Term8 and Term9	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term8",
+		NTType:     22,
+		Index:      54,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `Term8 : Term9	<<  >>`,
+		Id:         "Term8",
+		NTType:     22,
+		Index:      55,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `Term9 : Term9 shift Term10	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term9",
 		NTType:     23,
-		Index:      53,
+		Index:      56,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `Term9 : Term10	<<  >>`,
+		Id:         "Term9",
+		NTType:     23,
+		Index:      57,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `PrimaryExpr : Operand	<<  >>`,
-		Id:         "PrimaryExpr",
+		String:     `Term10 : Term10 "+" Term11	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term10",
 		NTType:     24,
-		Index:      54,
+		Index:      58,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `Term10 : Term10 "-" Term11	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term10",
+		NTType:     24,
+		Index:      59,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `Term10 : Term11	<<  >>`,
+		Id:         "Term10",
+		NTType:     24,
+		Index:      60,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `Term11 : Term11 product PrefixExpression	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "Term11",
+		NTType:     25,
+		Index:      61,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `Term11 : PrefixExpression	<<  >>`,
+		Id:         "Term11",
+		NTType:     25,
+		Index:      62,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `PrefixExpression : PowerExpression	<<  >>`,
+		Id:         "PrefixExpression",
+		NTType:     26,
+		Index:      63,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `PrefixExpression : PrefixOp PrefixExpression	<< ast.NewPrefixExpression(X[0].(*token.Token), X[1].(ast.Expression)) >>`,
+		Id:         "PrefixExpression",
+		NTType:     26,
+		Index:      64,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewPrefixExpression(X[0].(*token.Token), X[1].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `PowerExpression : Term12	<<  >>`,
+		Id:         "PowerExpression",
+		NTType:     27,
+		Index:      65,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `PowerExpression : Term12 power PrefixExpression	<< ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "PowerExpression",
+		NTType:     27,
+		Index:      66,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewInfixExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `Term12 : PrimaryExpr	<<  >>`,
+		Id:         "Term12",
+		NTType:     28,
+		Index:      67,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `Term12 : "(" Expression ")"	<< X[1], nil >>`,
+		Id:         "Term12",
+		NTType:     28,
+		Index:      68,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[1], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `PrefixOp : "+"	<<  >>`,
+		Id:         "PrefixOp",
+		NTType:     29,
+		Index:      69,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `PrefixOp : "-"	<<  >>`,
+		Id:         "PrefixOp",
+		NTType:     29,
+		Index:      70,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `PrefixOp : "!"	<<  >>`,
+		Id:         "PrefixOp",
+		NTType:     29,
+		Index:      71,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `PrefixOp : "~"	<<  >>`,
+		Id:         "PrefixOp",
+		NTType:     29,
+		Index:      72,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `PrimaryExpr : IndexExpression	<<  >>`,
+		String:     `PrimaryExpr : Operand	<<  >>`,
 		Id:         "PrimaryExpr",
-		NTType:     24,
-		Index:      55,
+		NTType:     30,
+		Index:      73,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `PrimaryExpr : CallExpression	<<  >>`,
+		String:     `PrimaryExpr : IndexExpression	<<  >>`,
 		Id:         "PrimaryExpr",
-		NTType:     24,
-		Index:      56,
+		NTType:     30,
+		Index:      74,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `PrimaryExpr : SelectorExpression	<<  >>`,
+		String:     `PrimaryExpr : SliceExpression	<<  >>`,
 		Id:         "PrimaryExpr",
-		NTType:     24,
-		Index:      57,
+		NTType:     30,
+		Index:      75,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `PrimaryExpr : CallExpression	<<  >>`,
+		Id:         "PrimaryExpr",
+		NTType:     30,
+		Index:      76,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `PrimaryExpr : SelectorExpression	<<  >>`,
+		Id:         "PrimaryExpr",
+		NTType:     30,
+		Index:      77,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `IndexExpression : Term12 "[" Expression "]"	<< ast.NewIndexExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		String:     `IndexExpression : Term12 "[" Expression "]"	<< ast.NewIndexExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
 		Id:         "IndexExpression",
-		NTType:     25,
-		Index:      58,
+		NTType:     31,
+		Index:      78,
 		NumSymbols: 4,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewIndexExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `CallExpression : Term12 "(" ")"	<< ast.NewCallExpression(X[0].(ast.Expression), X[1].(*token.Token), nil) >>`,
-		Id:         "CallExpression",
-		NTType:     26,
-		Index:      59,
-		NumSymbols: 3,
+		String:     `SliceExpression : Term12 "[" ":" "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, nil, nil) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      79,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, nil, nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" Expression ":" "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), nil, nil) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      80,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), nil, nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" ":" Expression "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, X[3].(ast.Expression), nil) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      81,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, X[3].(ast.Expression), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" Expression ":" Expression "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), X[4].(ast.Expression), nil) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      82,
+		NumSymbols: 6,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), X[4].(ast.Expression), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" ":" ":" "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, nil, nil) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      83,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, nil, nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" Expression ":" ":" "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), nil, nil) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      84,
+		NumSymbols: 6,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), nil, nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" ":" Expression ":" "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, X[3].(ast.Expression), nil) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      85,
+		NumSymbols: 6,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, X[3].(ast.Expression), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" Expression ":" Expression ":" "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), X[4].(ast.Expression), nil) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      86,
+		NumSymbols: 7,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), X[4].(ast.Expression), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" ":" ":" Expression "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, nil, X[4].(ast.Expression)) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      87,
+		NumSymbols: 6,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, nil, X[4].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" Expression ":" ":" Expression "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), nil, X[5].(ast.Expression)) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      88,
+		NumSymbols: 7,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), nil, X[5].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" ":" Expression ":" Expression "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, X[3].(ast.Expression), X[5].(ast.Expression)) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      89,
+		NumSymbols: 7,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), nil, X[3].(ast.Expression), X[5].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `SliceExpression : Term12 "[" Expression ":" Expression ":" Expression "]"	<< ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), X[4].(ast.Expression), X[6].(ast.Expression)) >>`,
+		Id:         "SliceExpression",
+		NTType:     32,
+		Index:      90,
+		NumSymbols: 8,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSliceExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression), X[4].(ast.Expression), X[6].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `CallExpression : Term12 "(" ")"	<< ast.NewCallExpression(X[0].(ast.Expression), X[1].(*token.Token), nil) >>`,
+		Id:         "CallExpression",
+		NTType:     33,
+		Index:      91,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewCallExpression(X[0].(ast.Expression), X[1].(*token.Token), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `CallExpression : Term12 "(" ExpressionList ")"	<< ast.NewCallExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.ExpressionList)) >>`,
+		Id:         "CallExpression",
+		NTType:     33,
+		Index:      92,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewCallExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.ExpressionList))
+		},
+	},
+	ProdTabEntry{
+		String:     `SelectorExpression : Term12 "." FieldName	<< ast.NewSelectorExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(*ast.Identifier)) >>`,
+		Id:         "SelectorExpression",
+		NTType:     34,
+		Index:      93,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSelectorExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(*ast.Identifier))
+		},
+	},
+	ProdTabEntry{
+		String:     `SelectorExpression : Term12 optDot FieldName	<< ast.NewSelectorExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(*ast.Identifier)) >>`,
+		Id:         "SelectorExpression",
+		NTType:     34,
+		Index:      94,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSelectorExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(*ast.Identifier))
+		},
+	},
+	ProdTabEntry{
+		String:     `FieldName : Identifier	<<  >>`,
+		Id:         "FieldName",
+		NTType:     35,
+		Index:      95,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `FieldName : kwdInf	<< ast.NewIdentifier(X[0].(*token.Token)) >>`,
+		Id:         "FieldName",
+		NTType:     35,
+		Index:      96,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewIdentifier(X[0].(*token.Token))
+		},
+	},
+	ProdTabEntry{
+		String:     `FieldName : kwdNan	<< ast.NewIdentifier(X[0].(*token.Token)) >>`,
+		Id:         "FieldName",
+		NTType:     35,
+		Index:      97,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewIdentifier(X[0].(*token.Token))
+		},
+	},
+	ProdTabEntry{
+		String:     `AssignExpression : Identifier assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "AssignExpression",
+		NTType:     36,
+		Index:      98,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `AssignExpression : IndexExpression assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "AssignExpression",
+		NTType:     36,
+		Index:      99,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `AssignExpression : SliceExpression assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "AssignExpression",
+		NTType:     36,
+		Index:      100,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `AssignExpression : SelectorExpression assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "AssignExpression",
+		NTType:     36,
+		Index:      101,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `AssignExpression : ArrayLiteral assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "AssignExpression",
+		NTType:     36,
+		Index:      102,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `AssignExpression : TupleLiteral assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "AssignExpression",
+		NTType:     36,
+		Index:      103,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `AssignExpression : HashLiteral assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "AssignExpression",
+		NTType:     36,
+		Index:      104,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `AssignExpression : SetLiteral assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "AssignExpression",
+		NTType:     36,
+		Index:      105,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `CompoundAssignExpression : Identifier opAssign Expression	<< ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "CompoundAssignExpression",
+		NTType:     37,
+		Index:      106,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `CompoundAssignExpression : IndexExpression opAssign Expression	<< ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "CompoundAssignExpression",
+		NTType:     37,
+		Index:      107,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `CompoundAssignExpression : SelectorExpression opAssign Expression	<< ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
+		Id:         "CompoundAssignExpression",
+		NTType:     37,
+		Index:      108,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `IncDecExpression : Identifier incDec	<< ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), nil) >>`,
+		Id:         "IncDecExpression",
+		NTType:     38,
+		Index:      109,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `IncDecExpression : IndexExpression incDec	<< ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), nil) >>`,
+		Id:         "IncDecExpression",
+		NTType:     38,
+		Index:      110,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `IncDecExpression : SelectorExpression incDec	<< ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), nil) >>`,
+		Id:         "IncDecExpression",
+		NTType:     38,
+		Index:      111,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewCompoundAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `IfExpression : kwdIf Expression BlockStatement	<< ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), nil) >>`,
+		Id:         "IfExpression",
+		NTType:     39,
+		Index:      112,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `IfExpression : kwdIf Expression BlockStatement kwdElse BlockStatement	<< ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), X[4].(*ast.BlockStatement)) >>`,
+		Id:         "IfExpression",
+		NTType:     39,
+		Index:      113,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), X[4].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `IfExpression : kwdIf Expression BlockStatement kwdElse IfExpression	<< ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), &ast.BlockStatement{Statements: ast.StatementList{ &ast.ExpressionStatement { Expression: X[4].(*ast.IfExpression) } }}) >>`,
+		Id:         "IfExpression",
+		NTType:     39,
+		Index:      114,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), &ast.BlockStatement{Statements: ast.StatementList{&ast.ExpressionStatement{Expression: X[4].(*ast.IfExpression)}}})
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, nil, nil, X[1].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      115,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), nil, nil, nil, X[1].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, X[1].(ast.Expression), nil, X[2].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      116,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), nil, X[1].(ast.Expression), nil, X[2].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor terminator terminator BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, nil, nil, X[3].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      117,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), nil, nil, nil, X[3].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor terminator terminator Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, nil, X[3].(ast.Expression), X[4].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      118,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), nil, nil, X[3].(ast.Expression), X[4].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor terminator Expression terminator BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, X[2].(ast.Expression), nil, X[4].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      119,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), nil, X[2].(ast.Expression), nil, X[4].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor terminator Expression terminator Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, X[2].(ast.Expression), X[4].(ast.Expression), X[5].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      120,
+		NumSymbols: 6,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), nil, X[2].(ast.Expression), X[4].(ast.Expression), X[5].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor Expression terminator terminator BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), nil, nil, X[4].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      121,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), nil, nil, X[4].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor Expression terminator terminator Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), nil, X[4].(ast.Expression), X[5].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      122,
+		NumSymbols: 6,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), nil, X[4].(ast.Expression), X[5].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor Expression terminator Expression terminator BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), nil, X[5].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      123,
+		NumSymbols: 6,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), nil, X[5].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor Expression terminator Expression terminator Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), X[5].(ast.Expression), X[6].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      124,
+		NumSymbols: 7,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), X[5].(ast.Expression), X[6].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor LiteralPattern kwdIn Expression BlockStatement	<< ast.NewForInExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), X[4].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      125,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForInExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), X[4].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor LiteralPattern "," PatternList kwdIn Expression BlockStatement	<< ast.NewForInExpression(X[0].(*token.Token), ast.NewTuplePattern(X[2].(*token.Token), X[1].(ast.Expression), X[3].(ast.ExpressionList)), X[5].(ast.Expression), X[6].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      126,
+		NumSymbols: 7,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewForInExpression(X[0].(*token.Token), ast.NewTuplePattern(X[2].(*token.Token), X[1].(ast.Expression), X[3].(ast.ExpressionList)), X[5].(ast.Expression), X[6].(*ast.BlockStatement))
+		},
+	},
+	ProdTabEntry{
+		String:     `ForExpression : kwdFor BlockPattern kwdIn Expression BlockStatement	<< ast.NewForInExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), X[4].(*ast.BlockStatement)) >>`,
+		Id:         "ForExpression",
+		NTType:     40,
+		Index:      127,
+		NumSymbols: 5,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewCallExpression(X[0].(ast.Expression), X[1].(*token.Token), nil)
+			return ast.NewForInExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), X[4].(*ast.BlockStatement))
 		},
 	},
 	ProdTabEntry{
-		String: `CallExpression : Term12 "(" ExpressionList ")"	<< ast.NewCallExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.ExpressionList)) >>`,
-		Id:         "CallExpression",
-		NTType:     26,
-		Index:      60,
+		String:     `StructDeclaration : kwdStruct Identifier "{" "}"	<< ast.NewStructDeclaration(X[0].(*token.Token), X[1].(*ast.Identifier), nil) >>`,
+		Id:         "StructDeclaration",
+		NTType:     41,
+		Index:      128,
 		NumSymbols: 4,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewCallExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.ExpressionList))
+			return ast.NewStructDeclaration(X[0].(*token.Token), X[1].(*ast.Identifier), nil)
 		},
 	},
 	ProdTabEntry{
-		String: `SelectorExpression : Term12 "." Identifier	<< ast.NewSelectorExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(*ast.Identifier)) >>`,
-		Id:         "SelectorExpression",
-		NTType:     27,
-		Index:      61,
-		NumSymbols: 3,
+		String:     `StructDeclaration : kwdStruct Identifier "{" StructMembers "}"	<< ast.NewStructDeclaration(X[0].(*token.Token), X[1].(*ast.Identifier), X[3].(ast.StructMembers)) >>`,
+		Id:         "StructDeclaration",
+		NTType:     41,
+		Index:      129,
+		NumSymbols: 5,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewSelectorExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(*ast.Identifier))
+			return ast.NewStructDeclaration(X[0].(*token.Token), X[1].(*ast.Identifier), X[3].(ast.StructMembers))
 		},
 	},
 	ProdTabEntry{
-		String: `AssignExpression : Identifier assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "AssignExpression",
-		NTType:     28,
-		Index:      62,
-		NumSymbols: 3,
+		String:     `StructMembers : StructMember	<< ast.NewStructMembers(X[0].(ast.StructMember)) >>`,
+		Id:         "StructMembers",
+		NTType:     42,
+		Index:      130,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return ast.NewStructMembers(X[0].(ast.StructMember))
 		},
 	},
 	ProdTabEntry{
-		String: `AssignExpression : IndexExpression assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "AssignExpression",
-		NTType:     28,
-		Index:      63,
+		String:     `StructMembers : StructMembers terminator StructMember	<< ast.AppendStructMember(X[0].(ast.StructMembers), X[2].(ast.StructMember)) >>`,
+		Id:         "StructMembers",
+		NTType:     42,
+		Index:      131,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return ast.AppendStructMember(X[0].(ast.StructMembers), X[2].(ast.StructMember))
 		},
 	},
 	ProdTabEntry{
-		String: `AssignExpression : SelectorExpression assign Expression	<< ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression)) >>`,
-		Id:         "AssignExpression",
-		NTType:     28,
-		Index:      64,
-		NumSymbols: 3,
+		String:     `StructMembers : StructMembers terminator	<< X[0], nil >>`,
+		Id:         "StructMembers",
+		NTType:     42,
+		Index:      132,
+		NumSymbols: 2,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewAssignExpression(X[0].(ast.Expression), X[1].(*token.Token), X[2].(ast.Expression))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `IfExpression : kwdIf Expression BlockStatement	<< ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), nil) >>`,
-		Id:         "IfExpression",
-		NTType:     29,
-		Index:      65,
+		String:     `StructMember : Identifier	<< ast.NewStructField(X[0].(*ast.Identifier), nil) >>`,
+		Id:         "StructMember",
+		NTType:     43,
+		Index:      133,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewStructField(X[0].(*ast.Identifier), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `StructMember : Identifier assign Expression	<< ast.NewStructField(X[0].(*ast.Identifier), X[2].(ast.Expression)) >>`,
+		Id:         "StructMember",
+		NTType:     43,
+		Index:      134,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), nil)
+			return ast.NewStructField(X[0].(*ast.Identifier), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `IfExpression : kwdIf Expression BlockStatement kwdElse BlockStatement	<< ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), X[4].(*ast.BlockStatement)) >>`,
-		Id:         "IfExpression",
-		NTType:     29,
-		Index:      66,
+		String:     `StructMember : kwdFn Identifier "(" ")" BlockStatement	<< ast.NewStructMethod(X[0].(*token.Token), X[1].(*ast.Identifier), nil, X[4].(*ast.BlockStatement)) >>`,
+		Id:         "StructMember",
+		NTType:     43,
+		Index:      135,
 		NumSymbols: 5,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), X[4].(*ast.BlockStatement))
+			return ast.NewStructMethod(X[0].(*token.Token), X[1].(*ast.Identifier), nil, X[4].(*ast.BlockStatement))
 		},
 	},
 	ProdTabEntry{
-		String: `IfExpression : kwdIf Expression BlockStatement kwdElse IfExpression	<< ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), &ast.BlockStatement{Statements: ast.StatementList{ &ast.ExpressionStatement { Expression: X[4].(*ast.IfExpression) } }}) >>`,
-		Id:         "IfExpression",
-		NTType:     29,
-		Index:      67,
-		NumSymbols: 5,
+		String:     `StructMember : kwdFn Identifier "(" PatternList ")" BlockStatement	<< ast.NewStructMethod(X[0].(*token.Token), X[1].(*ast.Identifier), X[3].(ast.ExpressionList), X[5].(*ast.BlockStatement)) >>`,
+		Id:         "StructMember",
+		NTType:     43,
+		Index:      136,
+		NumSymbols: 6,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewIfExpression(X[0].(*token.Token), X[1].(ast.Expression), X[2].(*ast.BlockStatement), &ast.BlockStatement{Statements: ast.StatementList{&ast.ExpressionStatement{Expression: X[4].(*ast.IfExpression)}}})
+			return ast.NewStructMethod(X[0].(*token.Token), X[1].(*ast.Identifier), X[3].(ast.ExpressionList), X[5].(*ast.BlockStatement))
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, nil, nil, X[1].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      68,
-		NumSymbols: 2,
+		String:     `Operand : Literal	<<  >>`,
+		Id:         "Operand",
+		NTType:     44,
+		Index:      137,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), nil, nil, nil, X[1].(*ast.BlockStatement))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, X[1].(ast.Expression), nil, X[2].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      69,
-		NumSymbols: 3,
+		String:     `Operand : Identifier	<<  >>`,
+		Id:         "Operand",
+		NTType:     44,
+		Index:      138,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), nil, X[1].(ast.Expression), nil, X[2].(*ast.BlockStatement))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor terminator terminator BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, nil, nil, X[3].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      70,
-		NumSymbols: 4,
+		String:     `PatternList : Pattern	<< ast.NewExpressionList(X[0].(ast.Expression)) >>`,
+		Id:         "PatternList",
+		NTType:     45,
+		Index:      139,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), nil, nil, nil, X[3].(*ast.BlockStatement))
+			return ast.NewExpressionList(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor terminator terminator Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, nil, X[3].(ast.Expression), X[4].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      71,
-		NumSymbols: 5,
+		String:     `PatternList : PatternList "," Pattern	<< ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression)) >>`,
+		Id:         "PatternList",
+		NTType:     45,
+		Index:      140,
+		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), nil, nil, X[3].(ast.Expression), X[4].(*ast.BlockStatement))
+			return ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor terminator Expression terminator BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, X[2].(ast.Expression), nil, X[4].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      72,
-		NumSymbols: 5,
+		String:     `Pattern : LiteralPattern	<<  >>`,
+		Id:         "Pattern",
+		NTType:     46,
+		Index:      141,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), nil, X[2].(ast.Expression), nil, X[4].(*ast.BlockStatement))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor terminator Expression terminator Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), nil, X[2].(ast.Expression), X[4].(ast.Expression), X[5].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      73,
-		NumSymbols: 6,
+		String:     `Pattern : "{" Identifier "}"	<< ast.NewSetLiteral(X[0].(*token.Token), ast.ExpressionList{X[1].(ast.Expression)}) >>`,
+		Id:         "Pattern",
+		NTType:     46,
+		Index:      142,
+		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), nil, X[2].(ast.Expression), X[4].(ast.Expression), X[5].(*ast.BlockStatement))
+			return ast.NewSetLiteral(X[0].(*token.Token), ast.ExpressionList{X[1].(ast.Expression)})
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor Expression terminator terminator BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), nil, nil, X[4].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      74,
-		NumSymbols: 5,
+		String:     `LiteralPattern : Identifier	<< ast.NewPattern(X[0].(ast.Expression)) >>`,
+		Id:         "LiteralPattern",
+		NTType:     47,
+		Index:      143,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), nil, nil, X[4].(*ast.BlockStatement))
+			return ast.NewPattern(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor Expression terminator terminator Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), nil, X[4].(ast.Expression), X[5].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      75,
-		NumSymbols: 6,
+		String:     `LiteralPattern : ArrayLiteral	<< ast.NewPattern(X[0].(ast.Expression)) >>`,
+		Id:         "LiteralPattern",
+		NTType:     47,
+		Index:      144,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), nil, X[4].(ast.Expression), X[5].(*ast.BlockStatement))
+			return ast.NewPattern(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor Expression terminator Expression terminator BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), nil, X[5].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      76,
-		NumSymbols: 6,
+		String:     `LiteralPattern : TupleLiteral	<< ast.NewPattern(X[0].(ast.Expression)) >>`,
+		Id:         "LiteralPattern",
+		NTType:     47,
+		Index:      145,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), nil, X[5].(*ast.BlockStatement))
+			return ast.NewPattern(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `ForExpression : kwdFor Expression terminator Expression terminator Expression BlockStatement	<< ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), X[5].(ast.Expression), X[6].(*ast.BlockStatement)) >>`,
-		Id:         "ForExpression",
-		NTType:     30,
-		Index:      77,
-		NumSymbols: 7,
+		String:     `LiteralPattern : HashLiteral	<< ast.NewPattern(X[0].(ast.Expression)) >>`,
+		Id:         "LiteralPattern",
+		NTType:     47,
+		Index:      146,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewForExpression(X[0].(*token.Token), X[1].(ast.Expression), X[3].(ast.Expression), X[5].(ast.Expression), X[6].(*ast.BlockStatement))
+			return ast.NewPattern(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Operand : Literal	<<  >>`,
-		Id:         "Operand",
-		NTType:     31,
-		Index:      78,
+		String:     `LiteralPattern : SetLiteral	<< ast.NewPattern(X[0].(ast.Expression)) >>`,
+		Id:         "LiteralPattern",
+		NTType:     47,
+		Index:      147,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewPattern(X[0].(ast.Expression))
 		},
 	},
 	ProdTabEntry{
-		String: `Operand : Identifier	<<  >>`,
-		Id:         "Operand",
-		NTType:     31,
-		Index:      79,
+		String:     `BlockPattern : BlockStatement	<< ast.NewBlockPattern(X[0].(*ast.BlockStatement)) >>`,
+		Id:         "BlockPattern",
+		NTType:     48,
+		Index:      148,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return X[0], nil
+			return ast.NewBlockPattern(X[0].(*ast.BlockStatement))
 		},
 	},
 	ProdTabEntry{
-		String: `IdentifierList : Identifier	<< ast.NewIdentifierList(X[0].(*ast.Identifier)) >>`,
-		Id:         "IdentifierList",
-		NTType:     32,
-		Index:      80,
+		String:     `Identifier : identifier	<< ast.NewIdentifier(X[0].(*token.Token)) >>`,
+		Id:         "Identifier",
+		NTType:     49,
+		Index:      149,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewIdentifierList(X[0].(*ast.Identifier))
+			return ast.NewIdentifier(X[0].(*token.Token))
 		},
 	},
 	ProdTabEntry{
-		String: `IdentifierList : IdentifierList "," Identifier	<< ast.AppendIdentifier(X[0].(ast.IdentifierList), X[2].(*ast.Identifier)) >>`,
-		Id:         "IdentifierList",
-		NTType:     32,
-		Index:      81,
-		NumSymbols: 3,
+		String:     `Literal : Null	<<  >>`,
+		Id:         "Literal",
+		NTType:     50,
+		Index:      150,
+		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.AppendIdentifier(X[0].(ast.IdentifierList), X[2].(*ast.Identifier))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Identifier : identifier	<< ast.NewIdentifier(X[0].(*token.Token)) >>`,
-		Id:         "Identifier",
-		NTType:     33,
-		Index:      82,
+		String:     `Literal : BooleanLiteral	<<  >>`,
+		Id:         "Literal",
+		NTType:     50,
+		Index:      151,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewIdentifier(X[0].(*token.Token))
+			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Literal : Null	<<  >>`,
+		String:     `Literal : IntegerLiteral	<<  >>`,
 		Id:         "Literal",
-		NTType:     34,
-		Index:      83,
+		NTType:     50,
+		Index:      152,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Literal : BooleanLiteral	<<  >>`,
+		String:     `Literal : FloatLiteral	<<  >>`,
 		Id:         "Literal",
-		NTType:     34,
-		Index:      84,
+		NTType:     50,
+		Index:      153,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Literal : IntegerLiteral	<<  >>`,
+		String:     `Literal : StringLiteral	<<  >>`,
 		Id:         "Literal",
-		NTType:     34,
-		Index:      85,
+		NTType:     50,
+		Index:      154,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Literal : FloatLiteral	<<  >>`,
+		String:     `Literal : FunctionLiteral	<<  >>`,
 		Id:         "Literal",
-		NTType:     34,
-		Index:      86,
+		NTType:     50,
+		Index:      155,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Literal : StringLiteral	<<  >>`,
+		String:     `Literal : ArrayLiteral	<<  >>`,
 		Id:         "Literal",
-		NTType:     34,
-		Index:      87,
+		NTType:     50,
+		Index:      156,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Literal : FunctionLiteral	<<  >>`,
+		String:     `Literal : TupleLiteral	<<  >>`,
 		Id:         "Literal",
-		NTType:     34,
-		Index:      88,
+		NTType:     50,
+		Index:      157,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Literal : ArrayLiteral	<<  >>`,
+		String:     `Literal : HashLiteral	<<  >>`,
 		Id:         "Literal",
-		NTType:     34,
-		Index:      89,
+		NTType:     50,
+		Index:      158,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Literal : HashLiteral	<<  >>`,
+		String:     `Literal : SetLiteral	<<  >>`,
 		Id:         "Literal",
-		NTType:     34,
-		Index:      90,
+		NTType:     50,
+		Index:      159,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return X[0], nil
 		},
 	},
 	ProdTabEntry{
-		String: `Null : kwdNull	<< ast.NewNull(X[0].(*token.Token)) >>`,
+		String:     `Null : kwdNull	<< ast.NewNull(X[0].(*token.Token)) >>`,
 		Id:         "Null",
-		NTType:     35,
-		Index:      91,
+		NTType:     51,
+		Index:      160,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewNull(X[0].(*token.Token))
 		},
 	},
 	ProdTabEntry{
-		String: `BooleanLiteral : boolLit	<< ast.NewBooleanLiteral(X[0].(*token.Token)) >>`,
+		String:     `BooleanLiteral : boolLit	<< ast.NewBooleanLiteral(X[0].(*token.Token)) >>`,
 		Id:         "BooleanLiteral",
-		NTType:     36,
-		Index:      92,
+		NTType:     52,
+		Index:      161,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewBooleanLiteral(X[0].(*token.Token))
 		},
 	},
 	ProdTabEntry{
-		String: `IntegerLiteral : intLit	<< ast.NewIntegerLiteral(X[0].(*token.Token)) >>`,
+		String:     `IntegerLiteral : intLit	<< ast.NewIntegerLiteral(X[0].(*token.Token)) >>`,
 		Id:         "IntegerLiteral",
-		NTType:     37,
-		Index:      93,
+		NTType:     53,
+		Index:      162,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewIntegerLiteral(X[0].(*token.Token))
 		},
 	},
 	ProdTabEntry{
-		String: `FloatLiteral : floatLit	<< ast.NewFloatLiteral(X[0].(*token.Token)) >>`,
+		String:     `FloatLiteral : floatLit	<< ast.NewFloatLiteral(X[0].(*token.Token)) >>`,
 		Id:         "FloatLiteral",
-		NTType:     38,
-		Index:      94,
+		NTType:     54,
+		Index:      163,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewFloatLiteral(X[0].(*token.Token))
+		},
+	},
+	ProdTabEntry{
+		String:     `FloatLiteral : kwdInf	<< ast.NewFloatLiteral(X[0].(*token.Token)) >>`,
+		Id:         "FloatLiteral",
+		NTType:     54,
+		Index:      164,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewFloatLiteral(X[0].(*token.Token))
+		},
+	},
+	ProdTabEntry{
+		String:     `FloatLiteral : kwdNan	<< ast.NewFloatLiteral(X[0].(*token.Token)) >>`,
+		Id:         "FloatLiteral",
+		NTType:     54,
+		Index:      165,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewFloatLiteral(X[0].(*token.Token))
 		},
 	},
 	ProdTabEntry{
-		String: `StringLiteral : stringLit	<< ast.NewStringLiteral(X[0].(*token.Token)) >>`,
+		String:     `StringLiteral : stringLit	<< ast.NewStringLiteral(X[0].(*token.Token)) >>`,
 		Id:         "StringLiteral",
-		NTType:     39,
-		Index:      95,
+		NTType:     55,
+		Index:      166,
 		NumSymbols: 1,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewStringLiteral(X[0].(*token.Token))
 		},
 	},
 	ProdTabEntry{
-		String: `FunctionLiteral : kwdFn "(" ")" BlockStatement	<< ast.NewFunctionLiteral(X[0].(*token.Token), nil, X[3].(*ast.BlockStatement)) >>`,
+		String:     `FunctionLiteral : kwdFn "(" ")" BlockStatement	<< ast.NewFunctionLiteral(X[0].(*token.Token), nil, X[3].(*ast.BlockStatement)) >>`,
 		Id:         "FunctionLiteral",
-		NTType:     40,
-		Index:      96,
+		NTType:     56,
+		Index:      167,
 		NumSymbols: 4,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewFunctionLiteral(X[0].(*token.Token), nil, X[3].(*ast.BlockStatement))
 		},
 	},
 	ProdTabEntry{
-		String: `FunctionLiteral : kwdFn "(" IdentifierList ")" BlockStatement	<< ast.NewFunctionLiteral(X[0].(*token.Token), X[2].(ast.IdentifierList), X[4].(*ast.BlockStatement)) >>`,
+		String:     `FunctionLiteral : kwdFn "(" PatternList ")" BlockStatement	<< ast.NewFunctionLiteral(X[0].(*token.Token), X[2].(ast.ExpressionList), X[4].(*ast.BlockStatement)) >>`,
 		Id:         "FunctionLiteral",
-		NTType:     40,
-		Index:      97,
+		NTType:     56,
+		Index:      168,
 		NumSymbols: 5,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
-			return ast.NewFunctionLiteral(X[0].(*token.Token), X[2].(ast.IdentifierList), X[4].(*ast.BlockStatement))
+			return ast.NewFunctionLiteral(X[0].(*token.Token), X[2].(ast.ExpressionList), X[4].(*ast.BlockStatement))
 		},
 	},
 	ProdTabEntry{
-		String: `ArrayLiteral : "[" "]"	<< ast.NewArrayLiteral(X[0].(*token.Token), nil) >>`,
+		String:     `ArrayLiteral : "[" "]"	<< ast.NewArrayLiteral(X[0].(*token.Token), nil) >>`,
 		Id:         "ArrayLiteral",
-		NTType:     41,
-		Index:      98,
+		NTType:     57,
+		Index:      169,
 		NumSymbols: 2,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewArrayLiteral(X[0].(*token.Token), nil)
 		},
 	},
 	ProdTabEntry{
-		String: `ArrayLiteral : "[" ExpressionList "]"	<< ast.NewArrayLiteral(X[0].(*token.Token), X[1].(ast.ExpressionList)) >>`,
+		String:     `ArrayLiteral : "[" ElementList "]"	<< ast.NewArrayLiteral(X[0].(*token.Token), X[1].(ast.ExpressionList)) >>`,
 		Id:         "ArrayLiteral",
-		NTType:     41,
-		Index:      99,
+		NTType:     57,
+		Index:      170,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewArrayLiteral(X[0].(*token.Token), X[1].(ast.ExpressionList))
 		},
 	},
 	ProdTabEntry{
-		String: `HashLiteral : "{" "," "}"	<< ast.NewHashLiteral(X[0].(*token.Token), nil) >>`,
+		String:     `ElementList : Element	<< ast.NewExpressionList(X[0].(ast.Expression)) >>`,
+		Id:         "ElementList",
+		NTType:     58,
+		Index:      171,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewExpressionList(X[0].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `ElementList : ElementList "," Element	<< ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression)) >>`,
+		Id:         "ElementList",
+		NTType:     58,
+		Index:      172,
+		NumSymbols: 3,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.AppendExpression(X[0].(ast.ExpressionList), X[2].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `ElementList : ElementList ","	<< X[0], nil >>`,
+		Id:         "ElementList",
+		NTType:     58,
+		Index:      173,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `Element : Expression	<<  >>`,
+		Id:         "Element",
+		NTType:     59,
+		Index:      174,
+		NumSymbols: 1,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return X[0], nil
+		},
+	},
+	ProdTabEntry{
+		String:     `Element : ellipsis Expression	<< ast.NewSpreadElement(X[0].(*token.Token), X[1].(ast.Expression)) >>`,
+		Id:         "Element",
+		NTType:     59,
+		Index:      175,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSpreadElement(X[0].(*token.Token), X[1].(ast.Expression))
+		},
+	},
+	ProdTabEntry{
+		String:     `TupleLiteral : "(" ")"	<< ast.NewTupleLiteral(X[0].(*token.Token), nil) >>`,
+		Id:         "TupleLiteral",
+		NTType:     60,
+		Index:      176,
+		NumSymbols: 2,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewTupleLiteral(X[0].(*token.Token), nil)
+		},
+	},
+	ProdTabEntry{
+		String:     `TupleLiteral : "(" Expression "," ")"	<< ast.NewTupleLiteral(X[0].(*token.Token), ast.ExpressionList{X[1].(ast.Expression)}) >>`,
+		Id:         "TupleLiteral",
+		NTType:     60,
+		Index:      177,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewTupleLiteral(X[0].(*token.Token), ast.ExpressionList{X[1].(ast.Expression)})
+		},
+	},
+	ProdTabEntry{
+		String:     `TupleLiteral : "(" Expression "," ExpressionList ")"	<< ast.NewTupleLiteral(X[0].(*token.Token), append(ast.ExpressionList{X[1].(ast.Expression)}, X[3].(ast.ExpressionList)...)) >>`,
+		Id:         "TupleLiteral",
+		NTType:     60,
+		Index:      178,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewTupleLiteral(X[0].(*token.Token), append(ast.ExpressionList{X[1].(ast.Expression)}, X[3].(ast.ExpressionList)...))
+		},
+	},
+	ProdTabEntry{
+		String:     `HashLiteral : "{" "," "}"	<< ast.NewHashLiteral(X[0].(*token.Token), nil) >>`,
 		Id:         "HashLiteral",
-		NTType:     42,
-		Index:      100,
+		NTType:     61,
+		Index:      179,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewHashLiteral(X[0].(*token.Token), nil)
 		},
 	},
 	ProdTabEntry{
-		String: `HashLiteral : "{" ExpressionMap "}"	<< ast.NewHashLiteral(X[0].(*token.Token), X[1].(ast.ExpressionMap)) >>`,
+		String:     `HashLiteral : "{" ExpressionMap "}"	<< ast.NewHashLiteral(X[0].(*token.Token), X[1].(ast.ExpressionMap)) >>`,
 		Id:         "HashLiteral",
-		NTType:     42,
-		Index:      101,
+		NTType:     61,
+		Index:      180,
 		NumSymbols: 3,
 		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
 			return ast.NewHashLiteral(X[0].(*token.Token), X[1].(ast.ExpressionMap))
 		},
 	},
+	ProdTabEntry{
+		String:     `SetLiteral : "{" Expression "," "}"	<< ast.NewSetLiteral(X[0].(*token.Token), ast.ExpressionList{X[1].(ast.Expression)}) >>`,
+		Id:         "SetLiteral",
+		NTType:     62,
+		Index:      181,
+		NumSymbols: 4,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSetLiteral(X[0].(*token.Token), ast.ExpressionList{X[1].(ast.Expression)})
+		},
+	},
+	ProdTabEntry{
+		String:     `SetLiteral : "{" Expression "," PlainExpressionList "}"	<< ast.NewSetLiteral(X[0].(*token.Token), append(ast.ExpressionList{X[1].(ast.Expression)}, X[3].(ast.ExpressionList)...)) >>`,
+		Id:         "SetLiteral",
+		NTType:     62,
+		Index:      182,
+		NumSymbols: 5,
+		ReduceFunc: func(X []Attrib, C interface{}) (Attrib, error) {
+			return ast.NewSetLiteral(X[0].(*token.Token), append(ast.ExpressionList{X[1].(ast.Expression)}, X[3].(ast.ExpressionList)...))
+		},
+	},
 }
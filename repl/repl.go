@@ -26,8 +26,12 @@ const ULang = `   __  ____    ___    _   ________
 `
 
 type Options struct {
-	Debug       bool
-	Interactive bool
+	Debug        bool
+	Interactive  bool
+	Roots        []string // directories accessible to the file system builtins
+	Seed         *int64   // seed of the random number generator, random if nil
+	AllowProcess bool     // enables environment variables, exec and exit hooks
+	StrictIndex  bool     // makes out-of-range index reads an IndexError
 }
 
 type REPL struct {
@@ -40,12 +44,23 @@ func New(user string, args []string, opts *Options) *REPL {
 	object.Stdin = os.Stdin
 	object.Stdout = os.Stdout
 	object.ExitFn = os.Exit
+	object.Roots = opts.Roots
+	object.AllowProcess = opts.AllowProcess
+	object.StrictIndex = opts.StrictIndex
 
 	return &REPL{user, args, opts}
 }
 
+func (r *REPL) newEnvironment() *object.Environment {
+	env := object.NewEnvironment()
+	if r.opts.Seed != nil {
+		env.Seed(*r.opts.Seed)
+	}
+	return env
+}
+
 func (r *REPL) Eval(f io.Reader) (env *object.Environment) {
-	env = object.NewEnvironment()
+	env = r.newEnvironment()
 
 	b, err := ioutil.ReadAll(f)
 	if err != nil {
@@ -62,7 +77,10 @@ func (r *REPL) Eval(f io.Reader) (env *object.Environment) {
 		return
 	}
 
-	eval.Eval(program.(*ast.Program), env)
+	result := eval.Run(program.(*ast.Program), env)
+	if err, ok := result.(*object.Error); ok {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Inspect())
+	}
 	return
 }
 
@@ -70,7 +88,7 @@ func (r *REPL) StartEvalLoop(in io.Reader, out io.Writer, env *object.Environmen
 	scanner := bufio.NewScanner(in)
 
 	if env == nil {
-		env = object.NewEnvironment()
+		env = r.newEnvironment()
 	}
 
 	p := parser.NewParser()
@@ -98,7 +116,7 @@ func (r *REPL) StartEvalLoop(in io.Reader, out io.Writer, env *object.Environmen
 			continue
 		}
 
-		obj := eval.Eval(program.(*ast.Program), env)
+		obj := eval.Run(program.(*ast.Program), env)
 		if obj != nil {
 			if _, ok := obj.(*object.Null); !ok {
 				io.WriteString(out, obj.Inspect()+"\n")
@@ -132,5 +150,8 @@ func (r *REPL) Run() {
 		if r.opts.Interactive {
 			r.StartEvalLoop(os.Stdin, os.Stdout, env)
 		}
+		if err := env.RunExitHooks(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err.Inspect())
+		}
 	}
 }
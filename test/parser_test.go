@@ -1,6 +1,7 @@
 package test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -89,6 +90,43 @@ func TestIndexExpression(t *testing.T) {
 	}
 }
 
+func TestSliceExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		bounds   [3]bool
+	}{
+		{"a[:]", "(a[:])", [3]bool{false, false, false}},
+		{"a[1:]", "(a[1:])", [3]bool{true, false, false}},
+		{"a[:-1]", "(a[:(-1)])", [3]bool{false, true, false}},
+		{"a[b:c]", "(a[b:c])", [3]bool{true, true, false}},
+		{"a[::]", "(a[:])", [3]bool{false, false, false}},
+		{"a[::-1]", "(a[::(-1)])", [3]bool{false, false, true}},
+		{"a[1:n + 1:2]", "(a[1:(n + 1):2])", [3]bool{true, true, true}},
+		{"f()[1:][0]", "((f()[1:])[0])", [3]bool{}},
+		{"a[1:2] = b", "((a[1:2]) = b)", [3]bool{}},
+	}
+
+	p := parser.NewParser()
+	for _, tt := range tests {
+		l := lexer.NewLexer([]byte(tt.input))
+		res, err := p.Parse(l)
+		assert.NoError(t, err)
+		assert.IsType(t, &ast.Program{}, res)
+
+		prog := res.(*ast.Program)
+		assert.Len(t, prog.Statements, 1)
+		assert.IsType(t, &ast.ExpressionStatement{}, prog.Statements[0])
+
+		expr := prog.Statements[0].(*ast.ExpressionStatement).Expression
+		assert.Equal(t, tt.expected, expr.String())
+
+		if slice, ok := expr.(*ast.SliceExpression); ok {
+			assert.Equal(t, tt.bounds, [3]bool{slice.Start != nil, slice.End != nil, slice.Step != nil})
+		}
+	}
+}
+
 func TestCallExpresion(t *testing.T) {
 	tests := []struct {
 		input            string
@@ -126,12 +164,15 @@ func TestCallExpresion(t *testing.T) {
 
 func TestSelectorExpression(t *testing.T) {
 	tests := []struct {
-		input         string
-		expectedLeft  string
-		expectedRight string
+		input            string
+		expectedLeft     string
+		expectedRight    string
+		expectedOptional bool
 	}{
-		{"a.b", "a", "b"},
-		{"(a + c).d", "(a + c)", "d"},
+		{"a.b", "a", "b", false},
+		{"(a + c).d", "(a + c)", "d", false},
+		{"a?.b", "a", "b", true},
+		{"a?.b.c", "a?.b", "c", false},
 	}
 
 	p := parser.NewParser()
@@ -151,6 +192,8 @@ func TestSelectorExpression(t *testing.T) {
 		sel := expr.(*ast.SelectorExpression)
 		assert.Equal(t, tt.expectedLeft, sel.Left.String())
 		assert.Equal(t, tt.expectedRight, sel.Right.String())
+		assert.Equal(t, tt.expectedOptional, sel.Optional)
+		assert.Equal(t, tt.input, sel.String())
 	}
 }
 
@@ -163,6 +206,16 @@ func TestAssignExpression(t *testing.T) {
 		{"a = b", "a", "b"},
 		{"a[0] = b", "(a[0])", "b"},
 		{"a.c = b", "a.c", "b"},
+		{"a, b = b, a", "(a, b)", "(b, a)"},
+		{"a, b = pair", "(a, b)", "pair"},
+		{"a, b.c, d[0] = 1, 2, f(x)", "(a, b.c, (d[0]))", "(1, 2, f(x))"},
+		{"a, b = c = d", "(a, b)", "(c = d)"},
+		{"(a, b) = t", "(a, b)", "t"},
+		{"[head, ...tail] = xs", "[head, ...tail]", "xs"},
+		{"[a, [b, c]] = xs", "[a, [b, c]]", "xs"},
+		{"{name, age} = p", "{name, age}", "p"},
+		{"{name} = p", "{name,}", "p"},
+		{`{"k": v, 1: [w,]} = h`, `{"k":v, 1:[w]}`, "h"},
 	}
 
 	p := parser.NewParser()
@@ -185,6 +238,84 @@ func TestAssignExpression(t *testing.T) {
 	}
 }
 
+func TestCompoundAssignExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		operator string
+	}{
+		{"a += 1", "(a += 1)", "+"},
+		{"a -= b * 2", "(a -= (b * 2))", "-"},
+		{"a[i] *= 2", "((a[i]) *= 2)", "*"},
+		{"a.b /= 2", "(a.b /= 2)", "/"},
+		{"a //= 2", "(a //= 2)", "//"},
+		{"a %= 2", "(a %= 2)", "%"},
+		{"a **= 2", "(a **= 2)", "**"},
+		{"a |= b", "(a |= b)", "|"},
+		{"a &= b", "(a &= b)", "&"},
+		{"a ^= b", "(a ^= b)", "^"},
+		{"a <<= 1", "(a <<= 1)", "<<"},
+		{"a >>= 1", "(a >>= 1)", ">>"},
+		{"a ??= b", "(a ??= b)", "??"},
+		{"a += b += 1", "(a += (b += 1))", "+"},
+		{"i++", "(i++)", "+"},
+		{"a[0]--", "((a[0])--)", "-"},
+		{"a.b++", "(a.b++)", "+"},
+	}
+
+	p := parser.NewParser()
+	for _, tt := range tests {
+		l := lexer.NewLexer([]byte(tt.input))
+		res, err := p.Parse(l)
+		assert.NoError(t, err)
+		assert.IsType(t, &ast.Program{}, res)
+
+		prog := res.(*ast.Program)
+		assert.Len(t, prog.Statements, 1)
+		assert.IsType(t, &ast.ExpressionStatement{}, prog.Statements[0])
+
+		expr := prog.Statements[0].(*ast.ExpressionStatement).Expression
+		assert.IsType(t, &ast.CompoundAssignExpression{}, expr)
+
+		assign := expr.(*ast.CompoundAssignExpression)
+		assert.Equal(t, tt.expected, assign.String())
+		assert.Equal(t, tt.operator, assign.Operator)
+	}
+
+	for _, input := range []string{"f(i++)", "x = i++", "a, b += 1", "1 += 1", "a[1:] += b"} {
+		_, err := p.Parse(lexer.NewLexer([]byte(input)))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestInvalidAssignmentTargets(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1, b = 1, 2", "cannot assign to 1"},
+		{"[a, b + 1] = xs", "cannot assign to (b + 1)"},
+		{"[a, ...b, ...c] = xs", "multiple spread elements in pattern [a, ...b, ...c]"},
+		{"{a, 1} = h", "expected a name in pattern {a, 1} got 1"},
+		{"fn ([a.b]) {}", "cannot bind to a.b"},
+		{"for [a[0]] in xs {}", "cannot bind to (a[0])"},
+		{"a?.b = 1", "cannot assign to a?.b"},
+		{"a?.b[0] = 1", "cannot assign to (a?.b[0])"},
+		{"a?.b.c += 1", "cannot assign to a?.b.c"},
+		{"{a + 1} = h", "expected a name in pattern {(a + 1)}"},
+		{"{a; b} = h", "expected a name in pattern {a; b}"},
+	}
+
+	p := parser.NewParser()
+	for _, tt := range tests {
+		l := lexer.NewLexer([]byte(tt.input))
+		_, err := p.Parse(l)
+		if assert.Error(t, err, tt.input) {
+			assert.Contains(t, err.Error(), tt.expected)
+		}
+	}
+}
+
 func TestForExpression(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -215,6 +346,75 @@ func TestForExpression(t *testing.T) {
 	}
 }
 
+func TestForInExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"for x in xs { x }", "for x in xs {\nx\n}"},
+		{"for index in [1, 2] + [3] { }", "for index in ([1, 2] + [3]) {}"},
+		{"for k, v in pairs {}", "for (k, v) in pairs {}"},
+		{"for i, [a, ...b] in xs {}", "for (i, [a, ...b]) in xs {}"},
+		{"for {name,} in people {}", "for {name,} in people {}"},
+		{"for {name} in people {}", "for {name,} in people {}"},
+	}
+
+	p := parser.NewParser()
+	for _, tt := range tests {
+		l := lexer.NewLexer([]byte(tt.input))
+		res, err := p.Parse(l)
+		assert.NoError(t, err)
+		assert.IsType(t, &ast.Program{}, res)
+
+		prog := res.(*ast.Program)
+		assert.Len(t, prog.Statements, 1)
+		assert.IsType(t, &ast.ExpressionStatement{}, prog.Statements[0])
+
+		expr := prog.Statements[0].(*ast.ExpressionStatement).Expression
+		assert.IsType(t, &ast.ForInExpression{}, expr)
+
+		f := expr.(*ast.ForInExpression)
+		assert.Equal(t, tt.expected, f.String())
+	}
+}
+
+func TestStructDeclaration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		fields   int
+		methods  int
+	}{
+		{"struct Empty {}", "struct Empty {}", 0, 0},
+		{"struct Point { x; y = 0; }", "struct Point {x; y = 0}", 2, 0},
+		{"struct Point { x; fn norm() { x }; y }", "struct Point {x; y; fn norm() {\nx\n}}", 2, 1},
+		{"struct Counter { fn add(n, m) { n + m } }", "struct Counter {fn add(n, m) {\n(n + m)\n}}", 0, 1},
+	}
+
+	p := parser.NewParser()
+	for _, tt := range tests {
+		l := lexer.NewLexer([]byte(tt.input))
+		res, err := p.Parse(l)
+		assert.NoError(t, err)
+		assert.IsType(t, &ast.Program{}, res)
+
+		prog := res.(*ast.Program)
+		assert.Len(t, prog.Statements, 1)
+		assert.IsType(t, &ast.ExpressionStatement{}, prog.Statements[0])
+
+		expr := prog.Statements[0].(*ast.ExpressionStatement).Expression
+		assert.IsType(t, &ast.StructDeclaration{}, expr)
+
+		decl := expr.(*ast.StructDeclaration)
+		assert.Equal(t, tt.expected, decl.String())
+		assert.Len(t, decl.Fields, tt.fields)
+		assert.Len(t, decl.Methods, tt.methods)
+	}
+
+	_, err := p.Parse(lexer.NewLexer([]byte("struct Point { x; fn x() {} }")))
+	assert.Error(t, err)
+}
+
 func TestIfExpression(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -357,6 +557,7 @@ func TestFloatLiteral(t *testing.T) {
 		{"15e10", 15e10},
 		{".2", .2},
 		{".1e5", .1e5},
+		{"inf", math.Inf(1)},
 	}
 
 	p := parser.NewParser()
@@ -408,11 +609,18 @@ func TestStringLiteral(t *testing.T) {
 
 func TestFunctionLiteral(t *testing.T) {
 	tests := []struct {
-		input    string
-		expected string
+		input       string
+		expected    string
+		isGenerator bool
 	}{
-		{"fn (test, test1) {}", "fn (test, test1) {}"},
-		{"fn () { test }", "fn () {\ntest\n}"},
+		{"fn (test, test1) {}", "fn (test, test1) {}", false},
+		{"fn () { test }", "fn () {\ntest\n}", false},
+		{"fn ([a, ...b], {c,}, (d, e)) {}", "fn ([a, ...b], {c,}, (d, e)) {}", false},
+		{"fn ({a}, {b}) {}", "fn ({a,}, {b,}) {}", false},
+		{"fn () { yield }", "fn () {\nyield\n}", true},
+		{"fn (n) { for i in n { yield i * 2 } }", "fn (n) {\nfor i in n {\nyield (i * 2)\n}\n}", true},
+		{"fn () { f(if a { yield 1 }) }", "fn () {\nf(if a {\nyield 1\n})\n}", true},
+		{"fn () { fn () { yield 1 } }", "fn () {\nfn () {\nyield 1\n}\n}", false},
 	}
 
 	p := parser.NewParser()
@@ -429,6 +637,7 @@ func TestFunctionLiteral(t *testing.T) {
 		expr := prog.Statements[0].(*ast.ExpressionStatement).Expression
 		assert.IsType(t, &ast.FunctionLiteral{}, expr)
 		assert.Equal(t, tt.expected, expr.String())
+		assert.Equal(t, tt.isGenerator, expr.(*ast.FunctionLiteral).IsGenerator)
 	}
 }
 
@@ -441,6 +650,7 @@ func TestArrayLiteral(t *testing.T) {
 		{"[a, b, c]", []string{"a", "b", "c"}},
 		{"[0.1, 1]", []string{"0.1", "1"}},
 		{`["test", true, 1]`, []string{"\"test\"", "true", "1"}},
+		{"[a, ...b, ...f(c)]", []string{"a", "...b", "...f(c)"}},
 	}
 
 	p := parser.NewParser()
@@ -465,6 +675,45 @@ func TestArrayLiteral(t *testing.T) {
 	}
 }
 
+func TestTupleLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"()", []string{}},
+		{"(a,)", []string{"a"}},
+		{"(a, b, c)", []string{"a", "b", "c"}},
+		{"(1, (2, 3),)", []string{"1", "(2, 3)"}},
+	}
+
+	p := parser.NewParser()
+	for _, tt := range tests {
+		l := lexer.NewLexer([]byte(tt.input))
+		res, err := p.Parse(l)
+		assert.NoError(t, err)
+		assert.IsType(t, &ast.Program{}, res)
+
+		prog := res.(*ast.Program)
+		assert.Len(t, prog.Statements, 1)
+		assert.IsType(t, &ast.ExpressionStatement{}, prog.Statements[0])
+
+		expr := prog.Statements[0].(*ast.ExpressionStatement).Expression
+		assert.IsType(t, &ast.TupleLiteral{}, expr)
+
+		tuple := expr.(*ast.TupleLiteral)
+		assert.Len(t, tuple.Elements, len(tt.expected))
+
+		for i, el := range tuple.Elements {
+			assert.Equal(t, tt.expected[i], el.String())
+		}
+	}
+
+	res, err := p.Parse(lexer.NewLexer([]byte("(a)")))
+	assert.NoError(t, err)
+	expr := res.(*ast.Program).Statements[0].(*ast.ExpressionStatement).Expression
+	assert.IsType(t, &ast.Identifier{}, expr)
+}
+
 func TestHashLiteral(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -490,9 +739,77 @@ func TestHashLiteral(t *testing.T) {
 		assert.IsType(t, &ast.HashLiteral{}, expr)
 
 		hash := expr.(*ast.HashLiteral)
-		for k, v := range hash.Pairs {
-			assert.Contains(t, tt.expected, k.String())
-			assert.Equal(t, tt.expected[k.String()], v.String())
+		assert.Len(t, hash.Pairs, len(tt.expected))
+		for _, pair := range hash.Pairs {
+			assert.Contains(t, tt.expected, pair.Key.String())
+			assert.Equal(t, tt.expected[pair.Key.String()], pair.Value.String())
 		}
 	}
 }
+
+func TestSetLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"{a,}", []string{"a"}},
+		{"{a, b, c}", []string{"a", "b", "c"}},
+		{`{1, "b", (2, 3),}`, []string{"1", `"b"`, "(2, 3)"}},
+	}
+
+	p := parser.NewParser()
+	for _, tt := range tests {
+		l := lexer.NewLexer([]byte(tt.input))
+		res, err := p.Parse(l)
+		assert.NoError(t, err)
+		assert.IsType(t, &ast.Program{}, res)
+
+		prog := res.(*ast.Program)
+		assert.Len(t, prog.Statements, 1)
+		assert.IsType(t, &ast.ExpressionStatement{}, prog.Statements[0])
+
+		expr := prog.Statements[0].(*ast.ExpressionStatement).Expression
+		assert.IsType(t, &ast.SetLiteral{}, expr)
+
+		set := expr.(*ast.SetLiteral)
+		assert.Len(t, set.Elements, len(tt.expected))
+
+		for i, el := range set.Elements {
+			assert.Equal(t, tt.expected[i], el.String())
+		}
+	}
+}
+
+func TestOperatorPrecedence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a // b * c", "((a // b) * c)"},
+		{"a ** b ** c", "(a ** (b ** c))"},
+		{"-a ** b", "(-(a ** b))"},
+		{"a ** -b", "(a ** (-b))"},
+		{"a * b ** c", "(a * (b ** c))"},
+		{"-(a + b)", "(-(a + b))"},
+		{"!a || b", "((!a) || b)"},
+		{"a ?? b || c", "((a ?? b) || c)"},
+		{"a && b ?? c", "(a && (b ?? c))"},
+		{"a ?? b == c", "(a ?? (b == c))"},
+		{"a ?? b ?? c", "((a ?? b) ?? c)"},
+		{"a?.b ?? c", "(a?.b ?? c)"},
+		{"a # comment\n", "a"},
+		{"a /* comment */ // b", "(a // b)"},
+	}
+
+	p := parser.NewParser()
+	for _, tt := range tests {
+		l := lexer.NewLexer([]byte(tt.input))
+		res, err := p.Parse(l)
+		assert.NoError(t, err)
+		assert.IsType(t, &ast.Program{}, res)
+
+		prog := res.(*ast.Program)
+		assert.Len(t, prog.Statements, 1)
+		assert.Equal(t, tt.expected, prog.Statements[0].String())
+	}
+}
@@ -142,11 +142,13 @@ var TokMap = TokenMap{
 		"{",
 		"}",
 		"kwdReturn",
+		"kwdYield",
+		"assign",
 		",",
 		":",
 		"lOr",
 		"lAnd",
-		"lNot",
+		"nullish",
 		"equals",
 		"lessOrGreater",
 		"or",
@@ -156,6 +158,7 @@ var TokMap = TokenMap{
 		"+",
 		"-",
 		"product",
+		"power",
 		"(",
 		")",
 		"!",
@@ -163,17 +166,24 @@ var TokMap = TokenMap{
 		"[",
 		"]",
 		".",
-		"assign",
+		"optDot",
+		"kwdInf",
+		"kwdNan",
+		"opAssign",
+		"incDec",
 		"kwdIf",
 		"kwdElse",
 		"kwdFor",
+		"kwdIn",
+		"kwdStruct",
+		"kwdFn",
 		"identifier",
 		"kwdNull",
 		"boolLit",
 		"intLit",
 		"floatLit",
 		"stringLit",
-		"kwdFn",
+		"ellipsis",
 	},
 
 	idMap: map[string]Type{
@@ -183,37 +193,47 @@ var TokMap = TokenMap{
 		"{":             3,
 		"}":             4,
 		"kwdReturn":     5,
-		",":             6,
-		":":             7,
-		"lOr":           8,
-		"lAnd":          9,
-		"lNot":          10,
-		"equals":        11,
-		"lessOrGreater": 12,
-		"or":            13,
-		"xor":           14,
-		"and":           15,
-		"shift":         16,
-		"+":             17,
-		"-":             18,
-		"product":       19,
-		"(":             20,
-		")":             21,
-		"!":             22,
-		"~":             23,
-		"[":             24,
-		"]":             25,
-		".":             26,
-		"assign":        27,
-		"kwdIf":         28,
-		"kwdElse":       29,
-		"kwdFor":        30,
-		"identifier":    31,
-		"kwdNull":       32,
-		"boolLit":       33,
-		"intLit":        34,
-		"floatLit":      35,
-		"stringLit":     36,
-		"kwdFn":         37,
+		"kwdYield":      6,
+		"assign":        7,
+		",":             8,
+		":":             9,
+		"lOr":           10,
+		"lAnd":          11,
+		"nullish":       12,
+		"equals":        13,
+		"lessOrGreater": 14,
+		"or":            15,
+		"xor":           16,
+		"and":           17,
+		"shift":         18,
+		"+":             19,
+		"-":             20,
+		"product":       21,
+		"power":         22,
+		"(":             23,
+		")":             24,
+		"!":             25,
+		"~":             26,
+		"[":             27,
+		"]":             28,
+		".":             29,
+		"optDot":        30,
+		"kwdInf":        31,
+		"kwdNan":        32,
+		"opAssign":      33,
+		"incDec":        34,
+		"kwdIf":         35,
+		"kwdElse":       36,
+		"kwdFor":        37,
+		"kwdIn":         38,
+		"kwdStruct":     39,
+		"kwdFn":         40,
+		"identifier":    41,
+		"kwdNull":       42,
+		"boolLit":       43,
+		"intLit":        44,
+		"floatLit":      45,
+		"stringLit":     46,
+		"ellipsis":      47,
 	},
 }
@@ -67,3 +67,17 @@ func WithTypes(types ...object.Type) CheckFunc {
 		return nil
 	}
 }
+
+func Numeric() CheckFunc {
+	return func(name string, args []object.Object) error {
+		for i, arg := range args {
+			if arg.Type() != object.IntegerType && arg.Type() != object.FloatType {
+				return fmt.Errorf(
+					"TypeError: %s() expected argument #%d to be `int` or `float` got `%s`",
+					name, i+1, arg.Type(),
+				)
+			}
+		}
+		return nil
+	}
+}